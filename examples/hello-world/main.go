@@ -106,7 +106,7 @@ func main() {
 		inputDataType = inputs[0].DataType
 		inputShape = make([]int64, len(inputs[0].Shape))
 		for i, dim := range inputs[0].Shape {
-			inputShape[i] = int64(dim)
+			inputShape[i] = dim.Length()
 		}
 		fmt.Printf("Using model input info: name='%s', shape=%v, type=%d\n", inputName, inputShape, inputDataType)
 	} else {
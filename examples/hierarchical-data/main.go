@@ -14,8 +14,9 @@ import (
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run main.go <model_path>")
-		fmt.Println("Example: go run main.go model.xml")
+		fmt.Println("Usage: go run main.go <model_path> [input_layout]")
+		fmt.Println("Example: go run main.go model.xml NC")
+		fmt.Println("input_layout, such as NCHW, is set on every input that has no layout.")
 		fmt.Println("Note: This example uses float32 data and is aimed at models with fixed or dynamic shapes.")
 		fmt.Println("For embedding models (e.g. all-MiniLM-L6-v2) use the text-embedding example instead.")
 		os.Exit(1)
@@ -37,13 +38,6 @@ func main() {
 	}
 	defer model.Close()
 
-	// Compile model
-	compiled, err := core.CompileModel(model, "CPU")
-	if err != nil {
-		log.Fatalf("Failed to compile model: %v", err)
-	}
-	defer compiled.Close()
-
 	// Get model I/O information
 	inputs, _ := model.GetInputs()
 	outputs, _ := model.GetOutputs()
@@ -52,13 +46,40 @@ func main() {
 		log.Fatal("Model must have at least one input and one output")
 	}
 
+	// The batch dimension is located through the input layouts. A layout is
+	// only declared when the caller gives one; without it the batch
+	// dimension of an input with no layout stays unknown.
+	if len(os.Args) > 2 {
+		for _, port := range inputs {
+			if port.Layout == "" {
+				if err := model.SetLayout(port.Name, openvino.Layout(os.Args[2])); err != nil {
+					log.Printf("Warning: SetLayout(%s) failed: %v", port.Name, err)
+				}
+			}
+		}
+	}
+	if batch, err := model.GetBatch(); err == nil {
+		fmt.Printf("Model batch dimension: %v\n", batch)
+	} else {
+		fmt.Println("Model batch dimension: unknown (no input layout with N)")
+	}
+
+	inputs, _ = model.GetInputs()
+	outputs, _ = model.GetOutputs()
 	inputInfo := inputs[0]
 	outputInfo := outputs[0]
 
-	fmt.Printf("Model Input: %s, Shape: %v, Type: %v\n",
-		inputInfo.Name, inputInfo.Shape, inputInfo.DataType)
-	fmt.Printf("Model Output: %s, Shape: %v, Type: %v\n",
-		outputInfo.Name, outputInfo.Shape, outputInfo.DataType)
+	fmt.Printf("Model Input: %s, Shape: %v, Layout: %v, Type: %v\n",
+		inputInfo.Name, inputInfo.Shape, inputInfo.Layout, inputInfo.DataType)
+	fmt.Printf("Model Output: %s, Shape: %v, Layout: %v, Type: %v\n",
+		outputInfo.Name, outputInfo.Shape, outputInfo.Layout, outputInfo.DataType)
+
+	// Compile model
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		log.Fatalf("Failed to compile model: %v", err)
+	}
+	defer compiled.Close()
 
 	// Example 1: Process hierarchical data with multiple inference passes
 	fmt.Println("\n=== Example 1: Multi-level Processing ===")
@@ -108,8 +129,8 @@ func processMultiLevel(core *openvino.Core, compiled *openvino.CompiledModel,
 		}
 		defer sentenceTensor.Close()
 
-		// Resolve dynamic dimensions (-1) to concrete shape; use [1, sentence_len] for this input
-		inputShape := resolveShape(shapeToInt64(inputInfo.Shape), []int64{1, int64(len(sentence))})
+		// Resolve dynamic dimensions to a concrete shape; use [1, sentence_len] for this input
+		inputShape := resolveShape(inputInfo, []int64{1, int64(len(sentence))})
 		if len(inputShape) > 1 && inputShape[1] != int64(len(sentence)) {
 			err = sentenceTensor.SetShape(inputShape)
 			if err != nil {
@@ -203,8 +224,8 @@ func processWithPreallocatedOutput(compiled *openvino.CompiledModel,
 	}
 	defer req.Close()
 
-	// Pre-allocate output tensor (resolve dynamic -1 to concrete dimensions)
-	outputShape := resolveShape(shapeToInt64(outputInfo.Shape), nil)
+	// Pre-allocate output tensor (resolve dynamic dimensions to concrete values)
+	outputShape := resolveShape(outputInfo, nil)
 	outputTensor, err := openvino.NewTensor(outputInfo.DataType, outputShape)
 	if err != nil {
 		log.Fatalf("Failed to create output tensor: %v", err)
@@ -220,8 +241,8 @@ func processWithPreallocatedOutput(compiled *openvino.CompiledModel,
 
 	fmt.Printf("  Pre-allocated output tensor with shape: %v\n", outputShape)
 
-	// Set input (resolve dynamic -1 so shape is concrete)
-	inputShape := resolveShape(shapeToInt64(inputInfo.Shape), nil)
+	// Set input (resolve dynamic dimensions so the shape is concrete)
+	inputShape := resolveShape(inputInfo, nil)
 	size := int64(1)
 	for _, d := range inputShape {
 		size *= d
@@ -300,31 +321,39 @@ func processDynamicShapes(compiled *openvino.CompiledModel,
 	}
 }
 
-func shapeToInt64(s []int32) []int64 {
-	out := make([]int64, len(s))
-	for i, v := range s {
-		out[i] = int64(v)
+// batchIndex returns the position of the port's batch dimension according to
+// its layout, or -1 if the layout has none.
+func batchIndex(port openvino.PortInfo) int {
+	idx, ok := port.Layout.BatchIdx()
+	if !ok {
+		return -1
 	}
-	return out
+	if idx < 0 {
+		idx += len(port.Shape)
+	}
+	return idx
 }
 
-// resolveShape replaces dynamic dimensions (-1) with concrete values so tensors can be allocated.
-// OpenVINO cannot allocate memory for shape [-1, -1]; use concrete dimensions (e.g. 1, 128).
-func resolveShape(shape []int64, defaults []int64) []int64 {
+// resolveShape replaces dynamic dimensions with concrete values so tensors can be allocated.
+// OpenVINO cannot allocate memory for shape [?, ?]; use concrete dimensions (e.g. 1, 128).
+func resolveShape(port openvino.PortInfo, defaults []int64) []int64 {
+	shape := port.Shape
 	if len(defaults) < len(shape) {
-		// fallback defaults: batch=1, then 128 for remaining dims
+		// fallback defaults: 1 for a batch dimension the layout locates, 128 otherwise
 		defaults = make([]int64, len(shape))
-		defaults[0] = 1
-		for i := 1; i < len(shape); i++ {
+		for i := range shape {
 			defaults[i] = 128
 		}
+		if b := batchIndex(port); b >= 0 && b < len(shape) {
+			defaults[b] = 1
+		}
 	}
 	out := make([]int64, len(shape))
 	for i, d := range shape {
-		if d == -1 || d < 0 {
+		if d.IsDynamic() {
 			out[i] = defaults[i]
 		} else {
-			out[i] = d
+			out[i] = d.Length()
 		}
 	}
 	return out
@@ -189,8 +189,8 @@ func prepareTextInput(text string, inputs []openvino.PortInfo) ([]int64, []int64
 
 	if len(inputs) > 0 {
 		inputShape := inputs[0].Shape
-		if len(inputShape) >= 2 && inputShape[1] > 0 {
-			maxSeqLen = int(inputShape[1]) // -1 means dynamic; keep default
+		if len(inputShape) >= 2 && inputShape[1].IsStatic() {
+			maxSeqLen = int(inputShape[1].Length()) // dynamic; keep default
 		}
 	}
 
@@ -247,14 +247,14 @@ func setInputTensors(request *openvino.InferRequest, inputs []openvino.PortInfo,
 		if len(input.Shape) > 0 {
 			shape = make([]int64, len(input.Shape))
 			for i, dim := range input.Shape {
-				if dim == -1 {
+				if dim.IsDynamic() {
 					if i == 1 {
 						shape[i] = int64(len(inputIDs))
 					} else {
 						shape[i] = 1
 					}
 				} else {
-					shape[i] = int64(dim)
+					shape[i] = dim.Length()
 				}
 			}
 		} else {
@@ -329,7 +329,7 @@ func getOutputTensor(request *openvino.InferRequest, outputs []openvino.PortInfo
 	return outputTensor, nil
 }
 
-func extractEmbedding(outputData []float32, outputShape []int32, seqLen int) []float32 {
+func extractEmbedding(outputData []float32, outputShape []int64, seqLen int) []float32 {
 	if len(outputShape) == 0 {
 		return outputData
 	}
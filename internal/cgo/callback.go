@@ -6,90 +6,107 @@ package cgo
 
 #include "core_wrapper.h"
 #include <stdlib.h>
+#include <stdint.h>
 
-extern void openvinoGoCallbackBridge(void* user_data, int32_t has_error, char* error_msg);
+extern void openvinoGoCallbackBridge(uintptr_t handle, int32_t error_code, char* error_msg);
 */
 import "C"
 import (
-	"errors"
+	"log"
+	rtcgo "runtime/cgo"
+	"runtime/debug"
 	"sync"
 	"unsafe"
 )
 
+// callbackSlot holds the completion callback of one request. The wrapper
+// keeps the slot's handle for the lifetime of the request, so the callback
+// can be swapped between runs without racing a completion in flight.
+type callbackSlot struct {
+	mu sync.Mutex
+	fn func(error)
+}
+
 var (
-	callbackRegistry = make(map[unsafe.Pointer]chan error)
-	callbackMutex    sync.RWMutex
+	callbackMutex sync.Mutex
+	// callbackHandles maps each request with a callback slot to the handle
+	// registered with the wrapper. Entries are removed by Destroy.
+	callbackHandles = make(map[*InferRequest]rtcgo.Handle)
 )
 
 //export openvinoGoCallbackBridge
-func openvinoGoCallbackBridge(userData unsafe.Pointer, hasError C.int32_t, errorMsg *C.char) {
-	if userData == nil {
-		return
-	}
-
-	callbackMutex.RLock()
-	callbackChan, exists := callbackRegistry[userData]
-	callbackMutex.RUnlock()
-
-	if !exists {
+func openvinoGoCallbackBridge(handle C.uintptr_t, errorCode C.int32_t, errorMsg *C.char) {
+	slot := rtcgo.Handle(handle).Value().(*callbackSlot)
+	slot.mu.Lock()
+	fn := slot.fn
+	slot.mu.Unlock()
+	if fn == nil {
 		return
 	}
 
 	var err error
-	if hasError != 0 && errorMsg != nil {
-		err = errors.New(C.GoString(errorMsg))
+	if errorCode != 0 {
+		err = &Error{Code: int32(errorCode), Message: C.GoString(errorMsg)}
 	}
+	// Run the callback off the OpenVINO thread so that it may block or
+	// start the next inference on the same request.
+	go runCallback(fn, err)
+}
 
-	select {
-	case callbackChan <- err:
-	default:
-	}
+// runCallback calls fn, recovering a panic so that a faulty callback cannot
+// take down the process from a goroutine the caller does not control.
+func runCallback(fn func(error), err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("openvino: panic in infer request callback: %v\n%s", r, debug.Stack())
+		}
+	}()
+	fn(err)
 }
 
+// SetCallback installs callback to be called after every asynchronous
+// inference of the request, until it is replaced, cleared with nil or the
+// request is destroyed. It may be called between runs; a completion already
+// being delivered uses the callback that was set when it fired.
 func (ir *InferRequest) SetCallback(callback func(error)) error {
+	callbackMutex.Lock()
+	defer callbackMutex.Unlock()
+
+	h, exists := callbackHandles[ir]
 	if callback == nil {
-		C.openvino_infer_request_clear_callback(C.OpenVINOInferRequest(unsafe.Pointer(ir)))
+		if exists {
+			C.openvino_infer_request_clear_callback(C.OpenVINOInferRequest(unsafe.Pointer(ir)))
+			slot := h.Value().(*callbackSlot)
+			slot.mu.Lock()
+			slot.fn = nil
+			slot.mu.Unlock()
+		}
 		return nil
 	}
 
-	callbackChan := make(chan error, 1)
-	requestPtr := unsafe.Pointer(ir)
-
-	callbackMutex.Lock()
-	if oldChan, exists := callbackRegistry[requestPtr]; exists {
-		close(oldChan)
-		delete(callbackRegistry, requestPtr)
+	if !exists {
+		h = rtcgo.NewHandle(&callbackSlot{})
 	}
-	callbackRegistry[requestPtr] = callbackChan
-	callbackMutex.Unlock()
-
-	go func() {
-		err := <-callbackChan
-		callback(err)
-		callbackMutex.Lock()
-		delete(callbackRegistry, requestPtr)
-		callbackMutex.Unlock()
-	}()
-
-	cCallback := C.OpenVINOCallback(C.openvinoGoCallbackBridge)
-	userData := requestPtr
+	slot := h.Value().(*callbackSlot)
+	slot.mu.Lock()
+	prev := slot.fn
+	slot.fn = callback
+	slot.mu.Unlock()
 
 	var cErr C.OpenVINOError
 	result := C.openvino_infer_request_set_callback(
 		C.OpenVINOInferRequest(unsafe.Pointer(ir)),
-		cCallback,
-		userData,
+		C.OpenVINOCallback(C.openvinoGoCallbackBridge),
+		C.uintptr_t(h),
 		&cErr,
 	)
-
 	if result != 0 {
-		callbackMutex.Lock()
-		if ch, exists := callbackRegistry[requestPtr]; exists {
-			close(ch)
-			delete(callbackRegistry, requestPtr)
+		slot.mu.Lock()
+		slot.fn = prev
+		slot.mu.Unlock()
+		if !exists {
+			h.Delete()
 		}
-		callbackMutex.Unlock()
-
 		err := &Error{
 			Code:    int32(cErr.code),
 			Message: C.GoString(cErr.message),
@@ -97,6 +114,16 @@ func (ir *InferRequest) SetCallback(callback func(error)) error {
 		C.openvino_error_free(&cErr)
 		return err
 	}
-
+	callbackHandles[ir] = h
 	return nil
 }
+
+// releaseCallback drops the callback slot of a destroyed request.
+func releaseCallback(ir *InferRequest) {
+	callbackMutex.Lock()
+	defer callbackMutex.Unlock()
+	if h, ok := callbackHandles[ir]; ok {
+		h.Delete()
+		delete(callbackHandles, ir)
+	}
+}
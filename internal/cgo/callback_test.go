@@ -0,0 +1,28 @@
+package cgo
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunCallback(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	want := errors.New("failed")
+	var got error
+	runCallback(func(err error) { got = err }, want)
+	if got != want {
+		t.Errorf("callback received %v, want %v", got, want)
+	}
+
+	runCallback(func(error) { panic("boom") }, nil)
+	if !strings.Contains(buf.String(), "boom") {
+		t.Errorf("panic in callback was not logged: %q", buf.String())
+	}
+}
@@ -9,7 +9,8 @@ package cgo
 */
 import "C"
 import (
-	"strings"
+	"io"
+	rtcgo "runtime/cgo"
 	"unsafe"
 )
 
@@ -43,22 +44,8 @@ func (c *Core) CompileModelWithProperties(model *Model, device string, propertie
 	cDevice := C.CString(device)
 	defer C.free(unsafe.Pointer(cDevice))
 
-	var keysBuilder, valuesBuilder strings.Builder
-	first := true
-	for k, v := range properties {
-		if !first {
-			keysBuilder.WriteString(",")
-			valuesBuilder.WriteString(",")
-		}
-		keysBuilder.WriteString(k)
-		valuesBuilder.WriteString(v)
-		first = false
-	}
-
-	cKeys := C.CString(keysBuilder.String())
-	defer C.free(unsafe.Pointer(cKeys))
-	cValues := C.CString(valuesBuilder.String())
-	defer C.free(unsafe.Pointer(cValues))
+	cKeys, cValues, free := cProperties(properties)
+	defer free()
 
 	var cErr C.OpenVINOError
 	compiled := C.openvino_core_compile_model_with_properties(
@@ -89,6 +76,115 @@ func (cm *CompiledModel) Destroy() {
 	}
 }
 
+func (cm *CompiledModel) Export(w io.Writer) error {
+	s := newWriteStream(w)
+	handle := rtcgo.NewHandle(s)
+	defer handle.Delete()
+
+	var cErr C.OpenVINOError
+	result := C.openvino_compiled_model_export(
+		C.OpenVINOCompiledModel(unsafe.Pointer(cm)),
+		s.writeCallback(),
+		s.seekCallback(),
+		C.uintptr_t(handle),
+		&cErr,
+	)
+
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		if s.err != nil {
+			return s.err
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (c *Core) ImportModel(r io.Reader, device string, properties map[string]string) (*CompiledModel, error) {
+	cDevice := C.CString(device)
+	defer C.free(unsafe.Pointer(cDevice))
+
+	cKeys, cValues, free := cProperties(properties)
+	defer free()
+
+	s := newReadStream(r)
+	handle := rtcgo.NewHandle(s)
+	defer handle.Delete()
+
+	var cErr C.OpenVINOError
+	compiled := C.openvino_core_import_model(
+		C.OpenVINOCore(unsafe.Pointer(c)),
+		s.readCallback(),
+		s.seekCallback(),
+		C.uintptr_t(handle),
+		cDevice,
+		cKeys,
+		cValues,
+		C.int32_t(len(properties)),
+		&cErr,
+	)
+
+	if compiled == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, err
+	}
+
+	return (*CompiledModel)(unsafe.Pointer(compiled)), nil
+}
+
+func (cm *CompiledModel) GetProperty(name string) (Property, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var value C.OpenVINOPropertyValue
+	var cErr C.OpenVINOError
+	result := C.openvino_compiled_model_get_property(
+		C.OpenVINOCompiledModel(unsafe.Pointer(cm)),
+		cName,
+		&value,
+		&cErr,
+	)
+
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return Property{}, err
+	}
+
+	defer C.openvino_property_value_free(&value)
+
+	return propertyFromC(&value), nil
+}
+
+func (cm *CompiledModel) GetInputs() ([]PortInfo, error) {
+	var count C.int32_t
+	var cErr C.OpenVINOError
+	ports := C.openvino_compiled_model_get_inputs(C.OpenVINOCompiledModel(unsafe.Pointer(cm)), &count, &cErr)
+	return portInfos(ports, count, &cErr)
+}
+
+func (cm *CompiledModel) GetOutputs() ([]PortInfo, error) {
+	var count C.int32_t
+	var cErr C.OpenVINOError
+	ports := C.openvino_compiled_model_get_outputs(C.OpenVINOCompiledModel(unsafe.Pointer(cm)), &count, &cErr)
+	return portInfos(ports, count, &cErr)
+}
+
 func (cm *CompiledModel) ReleaseMemory() error {
 	var cErr C.OpenVINOError
 	result := C.openvino_compiled_model_release_memory(
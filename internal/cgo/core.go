@@ -63,6 +63,102 @@ func (c *Core) GetAvailableDevices() ([]string, error) {
 	return result, nil
 }
 
+func (c *Core) GetProperty(device, name string) (Property, error) {
+	cDevice := C.CString(device)
+	defer C.free(unsafe.Pointer(cDevice))
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var value C.OpenVINOPropertyValue
+	var cErr C.OpenVINOError
+	result := C.openvino_core_get_property(
+		C.OpenVINOCore(unsafe.Pointer(c)),
+		cDevice,
+		cName,
+		&value,
+		&cErr,
+	)
+
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return Property{}, err
+	}
+
+	defer C.openvino_property_value_free(&value)
+
+	return propertyFromC(&value), nil
+}
+
+func propertyFromC(value *C.OpenVINOPropertyValue) Property {
+	prop := Property{
+		Kind:   PropertyKind(value.kind),
+		String: C.GoString(value.string_value),
+		Int:    int64(value.int_value),
+		Float:  float64(value.float_value),
+	}
+	if value.list_count > 0 && value.list_values != nil {
+		list := unsafe.Slice(value.list_values, int(value.list_count))
+		prop.List = make([]string, len(list))
+		for i, s := range list {
+			prop.List[i] = C.GoString(s)
+		}
+	}
+	return prop
+}
+
+func (c *Core) SetProperty(device string, properties map[string]string) error {
+	cDevice := C.CString(device)
+	defer C.free(unsafe.Pointer(cDevice))
+
+	cKeys, cValues, free := cProperties(properties)
+	defer free()
+
+	var cErr C.OpenVINOError
+	result := C.openvino_core_set_property(
+		C.OpenVINOCore(unsafe.Pointer(c)),
+		cDevice,
+		cKeys,
+		cValues,
+		C.int32_t(len(properties)),
+		&cErr,
+	)
+
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return err
+	}
+
+	return nil
+}
+
+// cProperties converts properties into the parallel key and value arrays
+// expected by the C wrapper. The returned function frees the strings.
+func cProperties(properties map[string]string) (keys, values **C.char, free func()) {
+	if len(properties) == 0 {
+		return nil, nil, func() {}
+	}
+	cKeys := make([]*C.char, 0, len(properties))
+	cValues := make([]*C.char, 0, len(properties))
+	for k, v := range properties {
+		cKeys = append(cKeys, C.CString(k))
+		cValues = append(cValues, C.CString(v))
+	}
+	return &cKeys[0], &cValues[0], func() {
+		for i := range cKeys {
+			C.free(unsafe.Pointer(cKeys[i]))
+			C.free(unsafe.Pointer(cValues[i]))
+		}
+	}
+}
+
 func IsAvailable() bool {
 	core, err := CreateCore()
 	if err != nil {
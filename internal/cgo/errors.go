@@ -1,6 +1,21 @@
 package cgo
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Error codes set by the C wrapper, matching OPENVINO_ERROR_* in core_wrapper.h.
+const (
+	ErrorCodeGeneral           int32 = -1
+	ErrorCodeNotFound          int32 = -2
+	ErrorCodeNotImplemented    int32 = -3
+	ErrorCodeParameterMismatch int32 = -4
+	ErrorCodeBusy              int32 = -5
+	ErrorCodeCancelled         int32 = -6
+)
+
+var ErrUnsupportedType = errors.New("unsupported data type")
 
 type Error struct {
 	Code    int32
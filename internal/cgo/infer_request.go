@@ -7,9 +7,6 @@ package cgo
 #include "core_wrapper.h"
 #include <stdlib.h>
 #include <stdint.h>
-
-// Forward declaration for Go callback bridge
-extern void openvinoGoCallbackBridge(void* user_data, int32_t has_error, const char* error_msg);
 */
 import "C"
 import (
@@ -41,16 +38,36 @@ func (cm *CompiledModel) CreateInferRequest() (*InferRequest, error) {
 func (ir *InferRequest) Destroy() {
 	if ir != nil {
 		C.openvino_infer_request_destroy(C.OpenVINOInferRequest(unsafe.Pointer(ir)))
+		// No callback can fire once the request is gone.
+		releaseCallback(ir)
 	}
 }
 
+// GetInputs describes the inputs of the compiled model the request was
+// created from.
+func (ir *InferRequest) GetInputs() ([]PortInfo, error) {
+	var count C.int32_t
+	var cErr C.OpenVINOError
+	ports := C.openvino_infer_request_get_inputs(C.OpenVINOInferRequest(unsafe.Pointer(ir)), &count, &cErr)
+	return portInfos(ports, count, &cErr)
+}
+
+// GetOutputs describes the outputs of the compiled model the request was
+// created from.
+func (ir *InferRequest) GetOutputs() ([]PortInfo, error) {
+	var count C.int32_t
+	var cErr C.OpenVINOError
+	ports := C.openvino_infer_request_get_outputs(C.OpenVINOInferRequest(unsafe.Pointer(ir)), &count, &cErr)
+	return portInfos(ports, count, &cErr)
+}
+
 func (ir *InferRequest) SetInputTensor(name string, data interface{}, shape []int64, dataType DataType) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	cShape := make([]C.int32_t, len(shape))
+	cShape := make([]C.int64_t, len(shape))
 	for i, s := range shape {
-		cShape[i] = C.int32_t(s)
+		cShape[i] = C.int64_t(s)
 	}
 
 	var cErr C.OpenVINOError
@@ -78,7 +95,7 @@ func (ir *InferRequest) SetInputTensor(name string, data interface{}, shape []in
 	case []uint64:
 		dataPtr = unsafe.Pointer(&v[0])
 	default:
-		return errors.New("unsupported data type")
+		return ErrUnsupportedType
 	}
 
 	result := C.openvino_infer_request_set_input_tensor(
@@ -104,9 +121,9 @@ func (ir *InferRequest) SetInputTensor(name string, data interface{}, shape []in
 }
 
 func (ir *InferRequest) SetInputTensorByIndex(index int32, data interface{}, shape []int64, dataType DataType) error {
-	cShape := make([]C.int32_t, len(shape))
+	cShape := make([]C.int64_t, len(shape))
 	for i, s := range shape {
-		cShape[i] = C.int32_t(s)
+		cShape[i] = C.int64_t(s)
 	}
 
 	var cErr C.OpenVINOError
@@ -134,7 +151,7 @@ func (ir *InferRequest) SetInputTensorByIndex(index int32, data interface{}, sha
 	case []uint64:
 		dataPtr = unsafe.Pointer(&v[0])
 	default:
-		return errors.New("unsupported data type")
+		return ErrUnsupportedType
 	}
 
 	result := C.openvino_infer_request_set_input_tensor_by_index(
@@ -361,6 +378,33 @@ func (ir *InferRequest) SetTensor(name string, tensor *Tensor) error {
 	return nil
 }
 
+// SetInputTensorUnifiedByIndex sets tensor as the input at index, like
+// SetTensor does by name.
+func (ir *InferRequest) SetInputTensorUnifiedByIndex(index int32, tensor *Tensor) error {
+	if tensor == nil {
+		return errors.New("tensor cannot be nil")
+	}
+
+	var cErr C.OpenVINOError
+	result := C.openvino_infer_request_set_input_tensor_unified_by_index(
+		C.OpenVINOInferRequest(unsafe.Pointer(ir)),
+		C.int32_t(index),
+		C.OpenVINOTensor(unsafe.Pointer(tensor)),
+		&cErr,
+	)
+
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return err
+	}
+
+	return nil
+}
+
 func (ir *InferRequest) GetInputTensor(name string) (*Tensor, error) {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
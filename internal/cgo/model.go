@@ -9,6 +9,7 @@ package cgo
 */
 import "C"
 import (
+	"errors"
 	"unsafe"
 )
 
@@ -33,55 +34,188 @@ func (c *Core) ReadModel(modelPath string) (*Model, error) {
 	return (*Model)(unsafe.Pointer(model)), nil
 }
 
+func (c *Core) ReadModelWithWeights(modelPath, weightsPath string) (*Model, error) {
+	cPath := C.CString(modelPath)
+	defer C.free(unsafe.Pointer(cPath))
+	cWeightsPath := C.CString(weightsPath)
+	defer C.free(unsafe.Pointer(cWeightsPath))
+
+	var cErr C.OpenVINOError
+	model := C.openvino_core_read_model_with_weights(C.OpenVINOCore(unsafe.Pointer(c)), cPath, cWeightsPath, &cErr)
+
+	if model == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+
+	return (*Model)(unsafe.Pointer(model)), nil
+}
+
+func (c *Core) ReadModelFromMemory(modelData, weights []byte) (*Model, error) {
+	if len(modelData) == 0 {
+		return nil, errors.New("model data cannot be empty")
+	}
+
+	var weightsPtr unsafe.Pointer
+	if len(weights) > 0 {
+		weightsPtr = unsafe.Pointer(&weights[0])
+	}
+
+	var cErr C.OpenVINOError
+	model := C.openvino_core_read_model_from_memory(
+		C.OpenVINOCore(unsafe.Pointer(c)),
+		(*C.char)(unsafe.Pointer(&modelData[0])),
+		C.int64_t(len(modelData)),
+		weightsPtr,
+		C.int64_t(len(weights)),
+		&cErr,
+	)
+
+	if model == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+
+	return (*Model)(unsafe.Pointer(model)), nil
+}
+
 func (m *Model) Destroy() {
 	if m != nil {
 		C.openvino_model_destroy(C.OpenVINOModel(unsafe.Pointer(m)))
 	}
 }
 
-func (m *Model) GetInputs() ([]PortInfo, error) {
-	var count C.int32_t
+func (m *Model) Save(xmlPath string, compressToFP16 bool) error {
+	cPath := C.CString(xmlPath)
+	defer C.free(unsafe.Pointer(cPath))
+
 	var cErr C.OpenVINOError
+	result := C.openvino_model_save(
+		C.OpenVINOModel(unsafe.Pointer(m)),
+		cPath,
+		C.bool(compressToFP16),
+		&cErr,
+	)
 
-	ports := C.openvino_model_get_inputs(C.OpenVINOModel(unsafe.Pointer(m)), &count, &cErr)
-	if ports == nil {
+	if result != 0 {
 		err := &Error{
 			Code:    int32(cErr.code),
 			Message: C.GoString(cErr.message),
 		}
 		C.openvino_error_free(&cErr)
-		return nil, err
+		return err
 	}
 
-	defer C.openvino_model_free_port_info(ports, count)
+	return nil
+}
 
-	result := make([]PortInfo, int(count))
-	for i := 0; i < int(count); i++ {
-		port := (*C.OpenVINOPortInfo)(unsafe.Pointer(uintptr(unsafe.Pointer(ports)) + uintptr(i)*unsafe.Sizeof(C.OpenVINOPortInfo{})))
-		result[i] = PortInfo{
-			Name:     C.GoString(port.name),
-			DataType: DataType(port.data_type),
+// Reshape sets the shapes of the inputs named by names. mins[i] and maxs[i]
+// hold the per-dimension bounds of the i-th shape; a max of -1 is unbounded.
+func (m *Model) Reshape(names []string, mins, maxs [][]int64) error {
+	cNames := make([]*C.char, len(names))
+	for i, name := range names {
+		cNames[i] = C.CString(name)
+		defer C.free(unsafe.Pointer(cNames[i]))
+	}
+	return m.reshape(cNames, nil, mins, maxs)
+}
+
+// ReshapeByIndex is like Reshape but addresses inputs by index.
+func (m *Model) ReshapeByIndex(indices []int32, mins, maxs [][]int64) error {
+	cIndices := make([]C.int32_t, len(indices))
+	for i, index := range indices {
+		cIndices[i] = C.int32_t(index)
+	}
+	return m.reshape(nil, cIndices, mins, maxs)
+}
+
+func (m *Model) reshape(cNames []*C.char, cIndices []C.int32_t, mins, maxs [][]int64) error {
+	if len(mins) == 0 {
+		return errors.New("openvino: no shapes to reshape")
+	}
+	if len(mins) != len(maxs) {
+		return errors.New("openvino: shape bounds length mismatch")
+	}
+
+	ranks := make([]C.int32_t, len(mins))
+	var dimsMin, dimsMax []C.int64_t
+	for i := range mins {
+		if len(mins[i]) != len(maxs[i]) {
+			return errors.New("openvino: shape bounds length mismatch")
 		}
-		result[i].Shape = make([]int32, int(port.shape_size))
-		for j := 0; j < int(port.shape_size); j++ {
-			result[i].Shape[j] = int32(*(*C.int32_t)(unsafe.Pointer(uintptr(unsafe.Pointer(port.shape)) + uintptr(j)*unsafe.Sizeof(C.int32_t(0)))))
+		ranks[i] = C.int32_t(len(mins[i]))
+		for j := range mins[i] {
+			dimsMin = append(dimsMin, C.int64_t(mins[i][j]))
+			dimsMax = append(dimsMax, C.int64_t(maxs[i][j]))
 		}
 	}
+	// Keep the pointers valid for scalar (rank 0) shapes
+	dimsMin = append(dimsMin, 0)
+	dimsMax = append(dimsMax, 0)
 
-	return result, nil
+	var namesPtr **C.char
+	var indicesPtr *C.int32_t
+	if cNames != nil {
+		namesPtr = &cNames[0]
+	} else {
+		indicesPtr = &cIndices[0]
+	}
+
+	var cErr C.OpenVINOError
+	result := C.openvino_model_reshape(
+		C.OpenVINOModel(unsafe.Pointer(m)),
+		namesPtr,
+		indicesPtr,
+		&ranks[0],
+		&dimsMin[0],
+		&dimsMax[0],
+		C.int32_t(len(mins)),
+		&cErr,
+	)
+
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return err
+	}
+
+	return nil
 }
 
-func (m *Model) GetOutputs() ([]PortInfo, error) {
+func (m *Model) GetInputs() ([]PortInfo, error) {
 	var count C.int32_t
 	var cErr C.OpenVINOError
+	ports := C.openvino_model_get_inputs(C.OpenVINOModel(unsafe.Pointer(m)), &count, &cErr)
+	return portInfos(ports, count, &cErr)
+}
 
+func (m *Model) GetOutputs() ([]PortInfo, error) {
+	var count C.int32_t
+	var cErr C.OpenVINOError
 	ports := C.openvino_model_get_outputs(C.OpenVINOModel(unsafe.Pointer(m)), &count, &cErr)
+	return portInfos(ports, count, &cErr)
+}
+
+// portInfos converts and frees the port array returned by the wrapper, or
+// returns the error it reported if ports is nil.
+func portInfos(ports *C.OpenVINOPortInfo, count C.int32_t, cErr *C.OpenVINOError) ([]PortInfo, error) {
 	if ports == nil {
 		err := &Error{
 			Code:    int32(cErr.code),
 			Message: C.GoString(cErr.message),
 		}
-		C.openvino_error_free(&cErr)
+		C.openvino_error_free(cErr)
 		return nil, err
 	}
 
@@ -94,11 +228,73 @@ func (m *Model) GetOutputs() ([]PortInfo, error) {
 			Name:     C.GoString(port.name),
 			DataType: DataType(port.data_type),
 		}
-		result[i].Shape = make([]int32, int(port.shape_size))
-		for j := 0; j < int(port.shape_size); j++ {
-			result[i].Shape[j] = int32(*(*C.int32_t)(unsafe.Pointer(uintptr(unsafe.Pointer(port.shape)) + uintptr(j)*unsafe.Sizeof(C.int32_t(0)))))
-		}
+		result[i].Dims = portDims(port)
+		result[i].Layout = C.GoString(port.layout)
 	}
 
 	return result, nil
 }
+
+func (m *Model) SetLayout(name, layout string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cLayout := C.CString(layout)
+	defer C.free(unsafe.Pointer(cLayout))
+
+	var cErr C.OpenVINOError
+	result := C.openvino_model_set_layout(C.OpenVINOModel(unsafe.Pointer(m)), cName, cLayout, &cErr)
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return err
+	}
+	return nil
+}
+
+func (m *Model) GetBatch() (Dimension, error) {
+	var batchMin, batchMax C.int64_t
+	var cErr C.OpenVINOError
+	result := C.openvino_model_get_batch(C.OpenVINOModel(unsafe.Pointer(m)), &batchMin, &batchMax, &cErr)
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return Dimension{}, err
+	}
+	return Dimension{Min: int64(batchMin), Max: int64(batchMax)}, nil
+}
+
+func (m *Model) SetBatch(batch Dimension) error {
+	var cErr C.OpenVINOError
+	result := C.openvino_model_set_batch(C.OpenVINOModel(unsafe.Pointer(m)), C.int64_t(batch.Min), C.int64_t(batch.Max), &cErr)
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return err
+	}
+	return nil
+}
+
+// portDims converts a port's shape bounds; nil means the rank is dynamic.
+func portDims(port *C.OpenVINOPortInfo) []Dimension {
+	if port.shape_size < 0 {
+		return nil
+	}
+	dims := make([]Dimension, int(port.shape_size))
+	for j := range dims {
+		off := uintptr(j) * unsafe.Sizeof(C.int64_t(0))
+		dims[j] = Dimension{
+			Min: int64(*(*C.int64_t)(unsafe.Pointer(uintptr(unsafe.Pointer(port.shape_min)) + off))),
+			Max: int64(*(*C.int64_t)(unsafe.Pointer(uintptr(unsafe.Pointer(port.shape_max)) + off))),
+		}
+	}
+	return dims
+}
@@ -0,0 +1,190 @@
+package cgo
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../cwrapper
+#cgo LDFLAGS: -L${SRCDIR}/../cwrapper/prebuilt -Wl,-rpath,${SRCDIR}/../cwrapper/prebuilt -lopenvino_wrapper -lopenvino
+
+#include "core_wrapper.h"
+#include <stdlib.h>
+*/
+import "C"
+import "unsafe"
+
+type PrePostProcessor C.struct_openvino_preprocessor
+
+type preprocessStep func(ppp C.OpenVINOPrePostProcessor, name *C.char, index C.int32_t, cErr *C.OpenVINOError) C.int32_t
+
+func NewPrePostProcessor(m *Model) (*PrePostProcessor, error) {
+	var cErr C.OpenVINOError
+	ppp := C.openvino_preprocessor_new(C.OpenVINOModel(unsafe.Pointer(m)), &cErr)
+	if ppp == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+	return (*PrePostProcessor)(unsafe.Pointer(ppp)), nil
+}
+
+func (p *PrePostProcessor) Destroy() {
+	if p != nil {
+		C.openvino_preprocessor_destroy(C.OpenVINOPrePostProcessor(unsafe.Pointer(p)))
+	}
+}
+
+func (p *PrePostProcessor) Build() (*Model, error) {
+	var cErr C.OpenVINOError
+	model := C.openvino_preprocessor_build(C.OpenVINOPrePostProcessor(unsafe.Pointer(p)), &cErr)
+	if model == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+	return (*Model)(unsafe.Pointer(model)), nil
+}
+
+// run applies one step to the port with the given name, or to the port at
+// index when name is empty. An empty name and a negative index select the
+// only input or output.
+func (p *PrePostProcessor) run(name string, index int32, step preprocessStep) error {
+	var cName *C.char
+	if name != "" {
+		cName = C.CString(name)
+		defer C.free(unsafe.Pointer(cName))
+	}
+
+	var cErr C.OpenVINOError
+	result := step(C.OpenVINOPrePostProcessor(unsafe.Pointer(p)), cName, C.int32_t(index), &cErr)
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return err
+	}
+	return nil
+}
+
+func (p *PrePostProcessor) runLayout(name string, index int32, layout string, fn func(C.OpenVINOPrePostProcessor, *C.char, C.int32_t, *C.char, *C.OpenVINOError) C.int32_t) error {
+	cLayout := C.CString(layout)
+	defer C.free(unsafe.Pointer(cLayout))
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return fn(ppp, n, i, cLayout, cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputTensorSetElementType(name string, index int32, dataType DataType) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_tensor_set_element_type(ppp, n, i, C.int32_t(dataType), cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputTensorSetLayout(name string, index int32, layout string) error {
+	return p.runLayout(name, index, layout, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, l *C.char, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_tensor_set_layout(ppp, n, i, l, cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputTensorSetColorFormat(name string, index int32, format int32) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_tensor_set_color_format(ppp, n, i, C.int32_t(format), cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputTensorSetSpatialShape(name string, index int32, height, width int64) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_tensor_set_spatial_shape(ppp, n, i, C.int64_t(height), C.int64_t(width), cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputConvertElementType(name string, index int32, dataType DataType) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_convert_element_type(ppp, n, i, C.int32_t(dataType), cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputConvertColor(name string, index int32, format int32) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_convert_color(ppp, n, i, C.int32_t(format), cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputResize(name string, index int32, algorithm int32, height, width int64) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_resize(ppp, n, i, C.int32_t(algorithm), C.int64_t(height), C.int64_t(width), cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputMean(name string, index int32, values []float32) error {
+	cValues := make([]C.float, len(values))
+	for j, v := range values {
+		cValues[j] = C.float(v)
+	}
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_mean(ppp, n, i, &cValues[0], C.int32_t(len(cValues)), cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputScale(name string, index int32, values []float32) error {
+	cValues := make([]C.float, len(values))
+	for j, v := range values {
+		cValues[j] = C.float(v)
+	}
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_scale(ppp, n, i, &cValues[0], C.int32_t(len(cValues)), cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputConvertLayout(name string, index int32, layout string) error {
+	return p.runLayout(name, index, layout, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, l *C.char, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_convert_layout(ppp, n, i, l, cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputReverseChannels(name string, index int32) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_reverse_channels(ppp, n, i, cErr)
+	})
+}
+
+func (p *PrePostProcessor) InputModelSetLayout(name string, index int32, layout string) error {
+	return p.runLayout(name, index, layout, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, l *C.char, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_input_model_set_layout(ppp, n, i, l, cErr)
+	})
+}
+
+func (p *PrePostProcessor) OutputTensorSetElementType(name string, index int32, dataType DataType) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_output_tensor_set_element_type(ppp, n, i, C.int32_t(dataType), cErr)
+	})
+}
+
+func (p *PrePostProcessor) OutputTensorSetLayout(name string, index int32, layout string) error {
+	return p.runLayout(name, index, layout, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, l *C.char, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_output_tensor_set_layout(ppp, n, i, l, cErr)
+	})
+}
+
+func (p *PrePostProcessor) OutputConvertElementType(name string, index int32, dataType DataType) error {
+	return p.run(name, index, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_output_convert_element_type(ppp, n, i, C.int32_t(dataType), cErr)
+	})
+}
+
+func (p *PrePostProcessor) OutputConvertLayout(name string, index int32, layout string) error {
+	return p.runLayout(name, index, layout, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, l *C.char, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_output_convert_layout(ppp, n, i, l, cErr)
+	})
+}
+
+func (p *PrePostProcessor) OutputModelSetLayout(name string, index int32, layout string) error {
+	return p.runLayout(name, index, layout, func(ppp C.OpenVINOPrePostProcessor, n *C.char, i C.int32_t, l *C.char, cErr *C.OpenVINOError) C.int32_t {
+		return C.openvino_preprocessor_output_model_set_layout(ppp, n, i, l, cErr)
+	})
+}
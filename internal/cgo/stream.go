@@ -0,0 +1,120 @@
+package cgo
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../cwrapper
+#cgo LDFLAGS: -L${SRCDIR}/../cwrapper/prebuilt -Wl,-rpath,${SRCDIR}/../cwrapper/prebuilt -lopenvino_wrapper -lopenvino
+
+#include "core_wrapper.h"
+#include <stdlib.h>
+#include <stdint.h>
+
+extern int64_t openvinoGoStreamRead(uintptr_t handle, void* buffer, int64_t size);
+extern int64_t openvinoGoStreamWrite(uintptr_t handle, void* data, int64_t size);
+extern int64_t openvinoGoStreamSeek(uintptr_t handle, int64_t offset, int32_t whence);
+*/
+import "C"
+import (
+	"io"
+	rtcgo "runtime/cgo"
+	"unsafe"
+)
+
+// stream adapts a Go reader or writer to the C wrapper's stream callbacks.
+// Offsets seen by OpenVINO are relative to the position the stream had when
+// it was handed over. The first I/O error is kept so it can be reported
+// instead of the generic error OpenVINO raises once the stream fails.
+type stream struct {
+	r    io.Reader
+	w    io.Writer
+	s    io.Seeker
+	base int64
+	err  error
+}
+
+func newReadStream(r io.Reader) *stream {
+	st := &stream{r: r}
+	st.probeSeeker(r)
+	return st
+}
+
+func newWriteStream(w io.Writer) *stream {
+	st := &stream{w: w}
+	st.probeSeeker(w)
+	return st
+}
+
+// probeSeeker enables seeking only if v is an io.Seeker that actually
+// supports it; files such as pipes implement Seek but always fail.
+func (s *stream) probeSeeker(v interface{}) {
+	seeker, ok := v.(io.Seeker)
+	if !ok {
+		return
+	}
+	base, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return
+	}
+	s.s = seeker
+	s.base = base
+}
+
+func (s *stream) readCallback() C.OpenVINOStreamReadCallback {
+	return C.OpenVINOStreamReadCallback(C.openvinoGoStreamRead)
+}
+
+func (s *stream) writeCallback() C.OpenVINOStreamWriteCallback {
+	return C.OpenVINOStreamWriteCallback(C.openvinoGoStreamWrite)
+}
+
+// seekCallback returns the seek callback, or nil if the stream is not seekable.
+func (s *stream) seekCallback() C.OpenVINOStreamSeekCallback {
+	if s.s == nil {
+		return nil
+	}
+	return C.OpenVINOStreamSeekCallback(C.openvinoGoStreamSeek)
+}
+
+func (s *stream) fail(err error) C.int64_t {
+	if s.err == nil {
+		s.err = err
+	}
+	return -1
+}
+
+//export openvinoGoStreamRead
+func openvinoGoStreamRead(handle C.uintptr_t, buffer unsafe.Pointer, size C.int64_t) C.int64_t {
+	s := rtcgo.Handle(handle).Value().(*stream)
+	buf := unsafe.Slice((*byte)(buffer), int(size))
+	n, err := io.ReadAtLeast(s.r, buf, 1)
+	if err == io.EOF {
+		return 0
+	}
+	if err != nil {
+		return s.fail(err)
+	}
+	return C.int64_t(n)
+}
+
+//export openvinoGoStreamWrite
+func openvinoGoStreamWrite(handle C.uintptr_t, data unsafe.Pointer, size C.int64_t) C.int64_t {
+	s := rtcgo.Handle(handle).Value().(*stream)
+	n, err := s.w.Write(unsafe.Slice((*byte)(data), int(size)))
+	if err != nil {
+		return s.fail(err)
+	}
+	return C.int64_t(n)
+}
+
+//export openvinoGoStreamSeek
+func openvinoGoStreamSeek(handle C.uintptr_t, offset C.int64_t, whence C.int32_t) C.int64_t {
+	s := rtcgo.Handle(handle).Value().(*stream)
+	off := int64(offset)
+	if int(whence) == io.SeekStart {
+		off += s.base
+	}
+	pos, err := s.s.Seek(off, int(whence))
+	if err != nil {
+		return s.fail(err)
+	}
+	return C.int64_t(pos - s.base)
+}
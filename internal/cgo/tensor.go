@@ -9,10 +9,7 @@ package cgo
 #include <string.h>
 */
 import "C"
-import (
-	"errors"
-	"unsafe"
-)
+import "unsafe"
 
 type Tensor C.struct_openvino_tensor
 
@@ -79,49 +76,15 @@ func (t *Tensor) GetData() ([]byte, error) {
 		return nil, err
 	}
 
-	shape, err := t.GetShape()
+	// The byte size accounts for packed sub-byte element types.
+	dataSize, err := t.GetByteSize()
 	if err != nil {
 		return nil, err
 	}
-
-	totalElements := int64(1)
-	for _, dim := range shape {
-		totalElements *= int64(dim)
-	}
-
-	var elementSize int
-	switch DataType(dataType) {
-	case DataTypeFloat32:
-		elementSize = 4
-	case DataTypeInt64:
-		elementSize = 8
-	case DataTypeInt32:
-		elementSize = 4
-	case DataTypeUint8:
-		elementSize = 1
-	case DataTypeFloat64:
-		elementSize = 8
-	case DataTypeInt8:
-		elementSize = 1
-	case DataTypeUint16:
-		elementSize = 2
-	case DataTypeInt16:
-		elementSize = 2
-	case DataTypeUint32:
-		elementSize = 4
-	case DataTypeUint64:
-		elementSize = 8
-	case DataTypeFloat16:
-		elementSize = 2
-	case DataTypeBFloat16:
-		elementSize = 2
-	default:
-		elementSize = 4
-	}
-
-	dataSize := int(totalElements) * elementSize
 	data := make([]byte, dataSize)
-	C.memcpy(unsafe.Pointer(&data[0]), dataPtr, C.size_t(dataSize))
+	if dataSize > 0 {
+		C.memcpy(unsafe.Pointer(&data[0]), dataPtr, C.size_t(dataSize))
+	}
 
 	return data, nil
 }
@@ -261,7 +224,7 @@ func (t *Tensor) GetDataAsUint64() ([]uint64, error) {
 	return uints, nil
 }
 
-func (t *Tensor) GetShape() ([]int32, error) {
+func (t *Tensor) GetShape() ([]int64, error) {
 	var shapeSize C.int32_t
 	var cErr C.OpenVINOError
 
@@ -277,24 +240,117 @@ func (t *Tensor) GetShape() ([]int32, error) {
 
 	defer C.openvino_tensor_free_shape(shapePtr)
 
-	shape := make([]int32, int(shapeSize))
+	shape := make([]int64, int(shapeSize))
 	for i := 0; i < int(shapeSize); i++ {
-		shape[i] = int32(*(*C.int32_t)(unsafe.Pointer(uintptr(unsafe.Pointer(shapePtr)) + uintptr(i)*unsafe.Sizeof(C.int32_t(0)))))
+		shape[i] = int64(*(*C.int64_t)(unsafe.Pointer(uintptr(unsafe.Pointer(shapePtr)) + uintptr(i)*unsafe.Sizeof(C.int64_t(0)))))
 	}
 
 	return shape, nil
 }
 
+// GetStrides returns the distance in bytes between consecutive elements of
+// each dimension.
+func (t *Tensor) GetStrides() ([]int64, error) {
+	var rank C.int32_t
+	var cErr C.OpenVINOError
+
+	stridesPtr := C.openvino_tensor_get_strides(C.OpenVINOTensor(unsafe.Pointer(t)), &rank, &cErr)
+	if stridesPtr == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+	defer C.openvino_tensor_free_shape(stridesPtr)
+
+	strides := make([]int64, int(rank))
+	for i, s := range unsafe.Slice((*C.int64_t)(stridesPtr), int(rank)) {
+		strides[i] = int64(s)
+	}
+	return strides, nil
+}
+
+func (t *Tensor) IsContinuous() (bool, error) {
+	var cErr C.OpenVINOError
+	result := C.openvino_tensor_is_continuous(C.OpenVINOTensor(unsafe.Pointer(t)), &cErr)
+	if result < 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return false, err
+	}
+	return result == 1, nil
+}
+
+// ROI returns a tensor viewing the region [begin, end) of t, sharing its
+// memory.
+func (t *Tensor) ROI(begin, end []int64) (*Tensor, error) {
+	if len(begin) != len(end) {
+		return nil, &Error{Code: ErrorCodeParameterMismatch, Message: "begin and end must have the same rank"}
+	}
+	cBegin := make([]C.int64_t, len(begin)+1)
+	cEnd := make([]C.int64_t, len(end)+1)
+	for i := range begin {
+		cBegin[i] = C.int64_t(begin[i])
+		cEnd[i] = C.int64_t(end[i])
+	}
+
+	var cErr C.OpenVINOError
+	roi := C.openvino_tensor_new_roi(
+		C.OpenVINOTensor(unsafe.Pointer(t)),
+		&cBegin[0],
+		&cEnd[0],
+		C.int32_t(len(begin)),
+		&cErr,
+	)
+	if roi == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+	return (*Tensor)(unsafe.Pointer(roi)), nil
+}
+
+// CopyTo copies the elements of t into dst.
+func (t *Tensor) CopyTo(dst *Tensor) error {
+	var cErr C.OpenVINOError
+	result := C.openvino_tensor_copy_to(
+		C.OpenVINOTensor(unsafe.Pointer(t)),
+		C.OpenVINOTensor(unsafe.Pointer(dst)),
+		&cErr,
+	)
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return err
+	}
+	return nil
+}
+
 func NewTensor(dataType DataType, shape []int64) (*Tensor, error) {
-	cShape := make([]C.int32_t, len(shape))
+	cShape := make([]C.int64_t, len(shape))
 	for i, s := range shape {
-		cShape[i] = C.int32_t(s)
+		cShape[i] = C.int64_t(s)
+	}
+	var shapePtr *C.int64_t
+	if len(cShape) > 0 {
+		shapePtr = &cShape[0]
 	}
 
 	var cErr C.OpenVINOError
 	tensor := C.openvino_tensor_new(
 		C.int32_t(dataType),
-		&cShape[0],
+		shapePtr,
 		C.int32_t(len(shape)),
 		&cErr,
 	)
@@ -312,9 +368,13 @@ func NewTensor(dataType DataType, shape []int64) (*Tensor, error) {
 }
 
 func NewTensorWithData(dataType DataType, shape []int64, data interface{}) (*Tensor, error) {
-	cShape := make([]C.int32_t, len(shape))
+	cShape := make([]C.int64_t, len(shape))
 	for i, s := range shape {
-		cShape[i] = C.int32_t(s)
+		cShape[i] = C.int64_t(s)
+	}
+	var shapePtr *C.int64_t
+	if len(cShape) > 0 {
+		shapePtr = &cShape[0]
 	}
 
 	var dataPtr unsafe.Pointer
@@ -340,13 +400,13 @@ func NewTensorWithData(dataType DataType, shape []int64, data interface{}) (*Ten
 	case []uint64:
 		dataPtr = unsafe.Pointer(&v[0])
 	default:
-		return nil, errors.New("unsupported data type for tensor creation")
+		return nil, ErrUnsupportedType
 	}
 
 	var cErr C.OpenVINOError
 	tensor := C.openvino_tensor_new_with_data(
 		C.int32_t(dataType),
-		&cShape[0],
+		shapePtr,
 		C.int32_t(len(shape)),
 		dataPtr,
 		&cErr,
@@ -364,6 +424,56 @@ func NewTensorWithData(dataType DataType, shape []int64, data interface{}) (*Ten
 	return (*Tensor)(unsafe.Pointer(tensor)), nil
 }
 
+// NewTensorFromHostPtr wraps data without copying. The caller must pin data
+// and keep it pinned until the tensor and all its copies are released.
+func NewTensorFromHostPtr(dataType DataType, shape []int64, data unsafe.Pointer) (*Tensor, error) {
+	cShape := make([]C.int64_t, len(shape))
+	for i, s := range shape {
+		cShape[i] = C.int64_t(s)
+	}
+	var shapePtr *C.int64_t
+	if len(cShape) > 0 {
+		shapePtr = &cShape[0]
+	}
+
+	var cErr C.OpenVINOError
+	tensor := C.openvino_tensor_new_from_host_ptr(
+		C.int32_t(dataType),
+		shapePtr,
+		C.int32_t(len(shape)),
+		data,
+		&cErr,
+	)
+
+	if tensor == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+
+	return (*Tensor)(unsafe.Pointer(tensor)), nil
+}
+
+// DataPointer returns the address of the tensor's memory without copying it.
+func (t *Tensor) DataPointer() (unsafe.Pointer, error) {
+	var dataType C.int32_t
+	var cErr C.OpenVINOError
+
+	dataPtr := C.openvino_tensor_get_data(C.OpenVINOTensor(unsafe.Pointer(t)), &dataType, &cErr)
+	if dataPtr == nil {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+	return dataPtr, nil
+}
+
 func (t *Tensor) GetSize() (int64, error) {
 	var cErr C.OpenVINOError
 	size := C.openvino_tensor_get_size(C.OpenVINOTensor(unsafe.Pointer(t)), &cErr)
@@ -406,10 +516,82 @@ func (t *Tensor) GetElementType() (DataType, error) {
 	return DataType(dataType), nil
 }
 
+// SetStrings assigns the elements of a string tensor. data must hold one
+// string per element.
+func (t *Tensor) SetStrings(data []string) error {
+	total := 0
+	for _, s := range data {
+		total += len(s)
+	}
+	// The strings are passed as one buffer with offsets so that no Go
+	// pointers are handed to C.
+	buf := make([]byte, 0, total+1)
+	offsets := make([]C.int64_t, len(data)+1)
+	for i, s := range data {
+		buf = append(buf, s...)
+		offsets[i+1] = C.int64_t(len(buf))
+	}
+	buf = append(buf, 0)
+
+	var cErr C.OpenVINOError
+	result := C.openvino_tensor_set_strings(
+		C.OpenVINOTensor(unsafe.Pointer(t)),
+		(*C.char)(unsafe.Pointer(&buf[0])),
+		&offsets[0],
+		C.int64_t(len(data)),
+		&cErr,
+	)
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return err
+	}
+	return nil
+}
+
+// GetStrings returns a copy of the elements of a string tensor.
+func (t *Tensor) GetStrings() ([]string, error) {
+	size, err := t.GetSize()
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return []string{}, nil
+	}
+	ptrs := make([]*C.char, size)
+	lengths := make([]C.int64_t, size)
+
+	var cErr C.OpenVINOError
+	result := C.openvino_tensor_get_strings(
+		C.OpenVINOTensor(unsafe.Pointer(t)),
+		&ptrs[0],
+		&lengths[0],
+		C.int64_t(size),
+		&cErr,
+	)
+	if result != 0 {
+		err := &Error{
+			Code:    int32(cErr.code),
+			Message: C.GoString(cErr.message),
+		}
+		C.openvino_error_free(&cErr)
+		return nil, err
+	}
+
+	data := make([]string, size)
+	for i := range data {
+		data[i] = C.GoStringN(ptrs[i], C.int(lengths[i]))
+	}
+	return data, nil
+}
+
 func (t *Tensor) SetShape(shape []int64) error {
-	cShape := make([]C.int32_t, len(shape))
+	cShape := make([]C.int64_t, len(shape))
 	for i, s := range shape {
-		cShape[i] = C.int32_t(s)
+		cShape[i] = C.int64_t(s)
 	}
 
 	var cErr C.OpenVINOError
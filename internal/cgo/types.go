@@ -1,24 +1,136 @@
 package cgo
 
+import "fmt"
+
+// DataType identifies an OpenVINO element type. The values are shared with
+// the C wrapper.
 type DataType int32
 
 const (
-	DataTypeFloat32  DataType = 0
-	DataTypeInt64    DataType = 1
-	DataTypeInt32    DataType = 2
-	DataTypeUint8    DataType = 3
-	DataTypeFloat64  DataType = 4
-	DataTypeInt8     DataType = 5
-	DataTypeUint16   DataType = 6
-	DataTypeInt16    DataType = 7
-	DataTypeUint32   DataType = 8
-	DataTypeUint64   DataType = 9
-	DataTypeFloat16  DataType = 10
-	DataTypeBFloat16 DataType = 11
+	DataTypeFloat32    DataType = 0
+	DataTypeInt64      DataType = 1
+	DataTypeInt32      DataType = 2
+	DataTypeUint8      DataType = 3
+	DataTypeFloat64    DataType = 4
+	DataTypeInt8       DataType = 5
+	DataTypeUint16     DataType = 6
+	DataTypeInt16      DataType = 7
+	DataTypeUint32     DataType = 8
+	DataTypeUint64     DataType = 9
+	DataTypeFloat16    DataType = 10
+	DataTypeBFloat16   DataType = 11
+	DataTypeBoolean    DataType = 12
+	DataTypeUint1      DataType = 13
+	DataTypeUint2      DataType = 14
+	DataTypeUint3      DataType = 15
+	DataTypeUint4      DataType = 16
+	DataTypeUint6      DataType = 17
+	DataTypeInt4       DataType = 18
+	DataTypeNF4        DataType = 19
+	DataTypeFloat8E4M3 DataType = 20
+	DataTypeFloat8E5M2 DataType = 21
+	DataTypeString     DataType = 22
+	DataTypeFloat4E2M1 DataType = 23
+	DataTypeFloat8E8M0 DataType = 24
+	DataTypeDynamic    DataType = 25
 )
 
+var dataTypeInfo = [...]struct {
+	name     string
+	bitWidth int
+}{
+	DataTypeFloat32:    {"f32", 32},
+	DataTypeInt64:      {"i64", 64},
+	DataTypeInt32:      {"i32", 32},
+	DataTypeUint8:      {"u8", 8},
+	DataTypeFloat64:    {"f64", 64},
+	DataTypeInt8:       {"i8", 8},
+	DataTypeUint16:     {"u16", 16},
+	DataTypeInt16:      {"i16", 16},
+	DataTypeUint32:     {"u32", 32},
+	DataTypeUint64:     {"u64", 64},
+	DataTypeFloat16:    {"f16", 16},
+	DataTypeBFloat16:   {"bf16", 16},
+	DataTypeBoolean:    {"boolean", 8},
+	DataTypeUint1:      {"u1", 1},
+	DataTypeUint2:      {"u2", 2},
+	DataTypeUint3:      {"u3", 3},
+	DataTypeUint4:      {"u4", 4},
+	DataTypeUint6:      {"u6", 6},
+	DataTypeInt4:       {"i4", 4},
+	DataTypeNF4:        {"nf4", 4},
+	DataTypeFloat8E4M3: {"f8e4m3", 8},
+	DataTypeFloat8E5M2: {"f8e5m2", 8},
+	DataTypeString:     {"string", 0},
+	DataTypeFloat4E2M1: {"f4e2m1", 4},
+	DataTypeFloat8E8M0: {"f8e8m0", 8},
+	DataTypeDynamic:    {"dynamic", 0},
+}
+
+// String returns OpenVINO's name for the type, such as "f32" or "u4".
+func (d DataType) String() string {
+	if d >= 0 && int(d) < len(dataTypeInfo) {
+		return dataTypeInfo[d].name
+	}
+	return fmt.Sprintf("DataType(%d)", int32(d))
+}
+
+// BitWidth returns the number of bits one element occupies in tensor
+// memory. It is 0 for DataTypeString, whose elements are not stored inline,
+// and for DataTypeDynamic.
+func (d DataType) BitWidth() int {
+	if d >= 0 && int(d) < len(dataTypeInfo) {
+		return dataTypeInfo[d].bitWidth
+	}
+	return 0
+}
+
+// Size returns the number of bytes one element occupies, rounded up for
+// packed sub-byte types such as DataTypeUint4. Use ByteSize for the memory
+// of several elements.
+func (d DataType) Size() int {
+	return (d.BitWidth() + 7) / 8
+}
+
+// IsPacked reports whether elements of the type are smaller than a byte and
+// stored several to a byte.
+func (d DataType) IsPacked() bool {
+	bits := d.BitWidth()
+	return bits > 0 && bits < 8
+}
+
+// ByteSize returns the number of bytes n elements occupy in tensor memory,
+// packing sub-byte types.
+func (d DataType) ByteSize(n int64) int64 {
+	return (n*int64(d.BitWidth()) + 7) / 8
+}
+
+type Dimension struct {
+	Min int64
+	Max int64
+}
+
 type PortInfo struct {
 	Name     string
-	Shape    []int32
+	Dims     []Dimension
 	DataType DataType
+	Layout   string
+}
+
+type PropertyKind int32
+
+const (
+	PropertyKindString     PropertyKind = 0
+	PropertyKindInt        PropertyKind = 1
+	PropertyKindBool       PropertyKind = 2
+	PropertyKindStringList PropertyKind = 3
+	PropertyKindFloat      PropertyKind = 4
+)
+
+type Property struct {
+	Kind   PropertyKind
+	String string
+	Int    int64
+	Float  float64
+	List   []string
 }
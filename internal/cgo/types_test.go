@@ -0,0 +1,43 @@
+package cgo
+
+import "testing"
+
+func TestDataType(t *testing.T) {
+	tests := []struct {
+		dataType DataType
+		name     string
+		bitWidth int
+		size     int
+		bytes10  int64
+	}{
+		{DataTypeFloat32, "f32", 32, 4, 40},
+		{DataTypeBFloat16, "bf16", 16, 2, 20},
+		{DataTypeBoolean, "boolean", 8, 1, 10},
+		{DataTypeUint1, "u1", 1, 1, 2},
+		{DataTypeUint4, "u4", 4, 1, 5},
+		{DataTypeInt4, "i4", 4, 1, 5},
+		{DataTypeNF4, "nf4", 4, 1, 5},
+		{DataTypeUint6, "u6", 6, 1, 8},
+		{DataTypeFloat8E5M2, "f8e5m2", 8, 1, 10},
+		{DataTypeString, "string", 0, 0, 0},
+		{DataTypeDynamic, "dynamic", 0, 0, 0},
+		{DataType(99), "DataType(99)", 0, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.dataType.String(); got != tt.name {
+			t.Errorf("DataType(%d).String() = %q, want %q", int32(tt.dataType), got, tt.name)
+		}
+		if got := tt.dataType.BitWidth(); got != tt.bitWidth {
+			t.Errorf("%v.BitWidth() = %d, want %d", tt.dataType, got, tt.bitWidth)
+		}
+		if got := tt.dataType.Size(); got != tt.size {
+			t.Errorf("%v.Size() = %d, want %d", tt.dataType, got, tt.size)
+		}
+		if got := tt.dataType.ByteSize(10); got != tt.bytes10 {
+			t.Errorf("%v.ByteSize(10) = %d, want %d", tt.dataType, got, tt.bytes10)
+		}
+		if got, want := tt.dataType.IsPacked(), tt.bitWidth > 0 && tt.bitWidth < 8; got != want {
+			t.Errorf("%v.IsPacked() = %v, want %v", tt.dataType, got, want)
+		}
+	}
+}
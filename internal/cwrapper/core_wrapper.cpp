@@ -2,14 +2,19 @@
 
 #include "core_wrapper.h"
 #include <openvino/openvino.hpp>
+#include <openvino/core/preprocess/pre_post_process.hpp>
+#include <openvino/core/version.hpp>
 #include <string>
 #include <vector>
 #include <cstring>
 #include <memory>
-#include <sstream>
+#include <istream>
+#include <ostream>
 #include <map>
 #include <chrono>
-#include <mutex>
+#include <stdexcept>
+#include <algorithm>
+#include <climits>
 
 static void set_error(OpenVINOError* error, int32_t code, const char* message) {
     if (error) {
@@ -21,10 +26,51 @@ static void set_error(OpenVINOError* error, int32_t code, const char* message) {
     }
 }
 
+// Maps an exception to an OPENVINO_ERROR_* code by its type. OpenVINO has
+// dedicated types for busy, cancelled and not-implemented; anything else it
+// throws is a plain ov::Exception and reported as a general error.
+static int32_t classify_exception(const std::exception& e) {
+    if (dynamic_cast<const ov::Busy*>(&e)) {
+        return OPENVINO_ERROR_BUSY;
+    }
+    if (dynamic_cast<const ov::Cancelled*>(&e)) {
+        return OPENVINO_ERROR_CANCELLED;
+    }
+    if (dynamic_cast<const ov::NotImplemented*>(&e)) {
+        return OPENVINO_ERROR_NOT_IMPLEMENTED;
+    }
+    if (dynamic_cast<const std::out_of_range*>(&e)) {
+        return OPENVINO_ERROR_NOT_FOUND;
+    }
+    if (dynamic_cast<const std::invalid_argument*>(&e)) {
+        return OPENVINO_ERROR_PARAMETER_MISMATCH;
+    }
+    return OPENVINO_ERROR_GENERAL;
+}
+
 static void set_error_from_exception(OpenVINOError* error, const std::exception& e) {
-    set_error(error, -1, e.what());
+    set_error(error, classify_exception(e), e.what());
+}
+
+// Like set_error_from_exception for calls that name a device, but reports
+// not found when the device is not registered with the core.
+static void set_device_error_from_exception(OpenVINOError* error, ov::Core* core, const char* device, const std::exception& e) {
+    int32_t code = classify_exception(e);
+    if (code == OPENVINO_ERROR_GENERAL && device && device[0] != '\0') {
+        try {
+            core->get_versions(device);
+        } catch (...) {
+            code = OPENVINO_ERROR_NOT_FOUND;
+        }
+    }
+    set_error(error, code, e.what());
 }
 
+// Codes 23 and 24 need OpenVINO 2025.0 or newer.
+#if OPENVINO_VERSION_MAJOR >= 2025
+#define OPENVINO_GO_HAS_F4_F8E8M0 1
+#endif
+
 static ov::element::Type get_element_type(int32_t data_type) {
     switch (data_type) {
         case 0: return ov::element::f32;      // float32
@@ -39,19 +85,28 @@ static ov::element::Type get_element_type(int32_t data_type) {
         case 9: return ov::element::u64;        // uint64
         case 10: return ov::element::f16;      // float16
         case 11: return ov::element::bf16;      // bfloat16
-        default: return ov::element::f32;
+        case 12: return ov::element::boolean;  // boolean
+        case 13: return ov::element::u1;       // packed 1-bit unsigned
+        case 14: return ov::element::u2;       // packed 2-bit unsigned
+        case 15: return ov::element::u3;       // packed 3-bit unsigned
+        case 16: return ov::element::u4;       // packed 4-bit unsigned
+        case 17: return ov::element::u6;       // packed 6-bit unsigned
+        case 18: return ov::element::i4;       // packed 4-bit signed
+        case 19: return ov::element::nf4;      // packed 4-bit NormalFloat
+        case 20: return ov::element::f8e4m3;   // float8 e4m3
+        case 21: return ov::element::f8e5m2;   // float8 e5m2
+        case 22: return ov::element::string;   // std::string
+#ifdef OPENVINO_GO_HAS_F4_F8E8M0
+        case 23: return ov::element::f4e2m1;   // packed float4 e2m1
+        case 24: return ov::element::f8e8m0;   // float8 e8m0 scale
+#endif
+        case 25: return ov::element::dynamic;  // dynamic
+        default:
+            throw std::invalid_argument("unsupported data type " + std::to_string(data_type));
     }
 }
 
 
-static size_t calculate_total_elements(const int32_t* shape, int32_t shape_size) {
-    size_t total = 1;
-    for (int32_t i = 0; i < shape_size; i++) {
-        total *= static_cast<size_t>(shape[i]);
-    }
-    return total;
-}
-
 static int32_t element_type_to_int32(ov::element::Type type) {
     if (type == ov::element::f32) return 0;
     if (type == ov::element::i64) return 1;
@@ -65,7 +120,450 @@ static int32_t element_type_to_int32(ov::element::Type type) {
     if (type == ov::element::u64) return 9;
     if (type == ov::element::f16) return 10;
     if (type == ov::element::bf16) return 11;
-    return 0; // Default to float32
+    if (type == ov::element::boolean) return 12;
+    if (type == ov::element::u1) return 13;
+    if (type == ov::element::u2) return 14;
+    if (type == ov::element::u3) return 15;
+    if (type == ov::element::u4) return 16;
+    if (type == ov::element::u6) return 17;
+    if (type == ov::element::i4) return 18;
+    if (type == ov::element::nf4) return 19;
+    if (type == ov::element::f8e4m3) return 20;
+    if (type == ov::element::f8e5m2) return 21;
+    if (type == ov::element::string) return 22;
+#ifdef OPENVINO_GO_HAS_F4_F8E8M0
+    if (type == ov::element::f4e2m1) return 23;
+    if (type == ov::element::f8e8m0) return 24;
+#endif
+    return 25; // dynamic, or a type newer than this wrapper
+}
+
+// copy_host_data fills tensor from data, which holds the tensor's contents
+// in OpenVINO's memory layout, so sub-byte types are packed. String tensors
+// hold std::string objects and are filled with openvino_tensor_set_strings.
+static void copy_host_data(ov::Tensor& tensor, const void* data) {
+    if (tensor.get_element_type() == ov::element::string) {
+        throw std::invalid_argument("string tensors cannot be created from raw data");
+    }
+    std::memcpy(tensor.data(), data, tensor.get_byte_size());
+}
+
+// Parse parallel property key and value arrays into an ov::AnyMap
+static bool parse_properties(
+    const char** property_keys,
+    const char** property_values,
+    int32_t property_count,
+    ov::AnyMap& config,
+    OpenVINOError* error
+) {
+    if (property_count > 0 && (!property_keys || !property_values)) {
+        set_error(error, OPENVINO_ERROR_PARAMETER_MISMATCH, "Invalid property format");
+        return false;
+    }
+    std::map<std::string, std::string> props;
+    for (int32_t i = 0; i < property_count; i++) {
+        if (!property_keys[i] || !property_values[i]) {
+            set_error(error, OPENVINO_ERROR_PARAMETER_MISMATCH, "Invalid property format");
+            return false;
+        }
+        props[property_keys[i]] = property_values[i];
+    }
+
+    for (const auto& prop : props) {
+        // Handle PERFORMANCE_HINT as string
+        if (prop.first == "PERFORMANCE_HINT") {
+            config[prop.first] = prop.second; // "LATENCY" or "THROUGHPUT"
+        }
+        // Handle numeric properties
+        else if (prop.first == "INFERENCE_NUM_THREADS" || prop.first == "NUM_STREAMS") {
+            try {
+                int32_t int_val = std::stoi(prop.second);
+                config[prop.first] = int_val;
+            } catch (...) {
+                // If not an integer, use as string
+                config[prop.first] = prop.second;
+            }
+        }
+        // Handle other properties that might be numeric
+        else if (prop.first.find("STREAM") != std::string::npos ||
+                 prop.first.find("THREAD") != std::string::npos) {
+            try {
+                int32_t int_val = std::stoi(prop.second);
+                config[prop.first] = int_val;
+            } catch (...) {
+                config[prop.first] = prop.second;
+            }
+        }
+        // Default: use as string
+        else {
+            config[prop.first] = prop.second;
+        }
+    }
+
+    return true;
+}
+
+static char** strdup_list(const std::vector<std::string>& items) {
+    char** result = static_cast<char**>(malloc(sizeof(char*) * (items.empty() ? 1 : items.size())));
+    for (size_t i = 0; i < items.size(); i++) {
+        result[i] = strdup(items[i].c_str());
+    }
+    return result;
+}
+
+// Convert an ov::Any property value into its typed C representation
+static void fill_property_value(const ov::Any& any, OpenVINOPropertyValue* value) {
+    std::memset(value, 0, sizeof(OpenVINOPropertyValue));
+
+    std::string rendered;
+    try {
+        rendered = any.as<std::string>();
+    } catch (...) {
+    }
+
+    std::vector<std::string> list;
+    bool is_list = false;
+
+    if (any.is<bool>()) {
+        bool b = any.as<bool>();
+        value->kind = 2;
+        value->int_value = b ? 1 : 0;
+        rendered = b ? "YES" : "NO";
+    } else if (any.is<int32_t>()) {
+        value->kind = 1;
+        value->int_value = any.as<int32_t>();
+    } else if (any.is<uint32_t>()) {
+        value->kind = 1;
+        value->int_value = any.as<uint32_t>();
+    } else if (any.is<int64_t>()) {
+        value->kind = 1;
+        value->int_value = any.as<int64_t>();
+    } else if (any.is<uint64_t>()) {
+        value->kind = 1;
+        value->int_value = static_cast<int64_t>(any.as<uint64_t>());
+    } else if (any.is<float>()) {
+        value->kind = 4;
+        value->float_value = any.as<float>();
+    } else if (any.is<double>()) {
+        value->kind = 4;
+        value->float_value = any.as<double>();
+    } else if (any.is<std::vector<std::string>>()) {
+        list = any.as<std::vector<std::string>>();
+        is_list = true;
+    } else if (any.is<std::vector<ov::PropertyName>>()) {
+        for (const auto& name : any.as<std::vector<ov::PropertyName>>()) {
+            list.push_back(name);
+        }
+        is_list = true;
+    } else if (any.is<std::tuple<unsigned int, unsigned int, unsigned int>>()) {
+        auto range = any.as<std::tuple<unsigned int, unsigned int, unsigned int>>();
+        list = {std::to_string(std::get<0>(range)),
+                std::to_string(std::get<1>(range)),
+                std::to_string(std::get<2>(range))};
+        is_list = true;
+    } else if (any.is<std::tuple<unsigned int, unsigned int>>()) {
+        auto range = any.as<std::tuple<unsigned int, unsigned int>>();
+        list = {std::to_string(std::get<0>(range)), std::to_string(std::get<1>(range))};
+        is_list = true;
+    }
+
+    if (is_list) {
+        value->kind = 3;
+        value->list_count = static_cast<int32_t>(list.size());
+        value->list_values = strdup_list(list);
+    }
+
+    value->string_value = strdup(rendered.c_str());
+}
+
+static const size_t kStreamChunkSize = 1 << 16;
+
+// streambuf that pulls data from a caller-provided read callback in chunks.
+// OpenVINO only seeks back to positions it has asked for with tellg, so when
+// the source cannot seek, the data from the first such position on is held
+// in memory and forward seeks read ahead.
+class CallbackReadBuf : public std::streambuf {
+public:
+    CallbackReadBuf(OpenVINOStreamReadCallback read, OpenVINOStreamSeekCallback seek, uintptr_t handle)
+        : read_(read), seek_(seek), handle_(handle), buffer_(kStreamChunkSize), offset_(0), holding_(false) {
+        setg(buffer_.data(), buffer_.data(), buffer_.data());
+    }
+
+protected:
+    int_type underflow() override {
+        if (gptr() < egptr()) {
+            return traits_type::to_int_type(*gptr());
+        }
+        size_t kept = 0;
+        if (holding_) {
+            kept = static_cast<size_t>(egptr() - eback());
+            if (buffer_.size() < kept + kStreamChunkSize) {
+                buffer_.resize(std::max(buffer_.size() * 2, kept + kStreamChunkSize));
+            }
+        } else {
+            offset_ += egptr() - eback();
+        }
+        int64_t n = read_(handle_, buffer_.data() + kept, static_cast<int64_t>(kStreamChunkSize));
+        if (n <= 0) {
+            setg(buffer_.data(), buffer_.data() + kept, buffer_.data() + kept);
+            return traits_type::eof();
+        }
+        setg(buffer_.data(), buffer_.data() + kept, buffer_.data() + kept + n);
+        return traits_type::to_int_type(*gptr());
+    }
+
+    pos_type seekoff(off_type off, std::ios_base::seekdir dir, std::ios_base::openmode) override {
+        int64_t current = offset_ + (gptr() - eback());
+        if (dir == std::ios_base::cur && off == 0) {
+            if (!seek_) {
+                holding_ = true;
+            }
+            return pos_type(current);
+        }
+        if (!seek_) {
+            return seek_held(off, dir, current);
+        }
+        if (dir == std::ios_base::cur) {
+            return seek_to(current + off, 0);
+        }
+        return seek_to(off, dir == std::ios_base::beg ? 0 : 2);
+    }
+
+    pos_type seekpos(pos_type pos, std::ios_base::openmode which) override {
+        return seekoff(off_type(pos), std::ios_base::beg, which);
+    }
+
+private:
+    pos_type seek_to(int64_t offset, int32_t whence) {
+        int64_t pos = seek_(handle_, offset, whence);
+        if (pos < 0) {
+            return pos_type(off_type(-1));
+        }
+        offset_ = pos;
+        setg(buffer_.data(), buffer_.data(), buffer_.data());
+        return pos_type(pos);
+    }
+
+    // Seeks within the held data of a non-seekable source, reading ahead as
+    // needed. Seeking from the end holds and reads the rest of the source.
+    pos_type seek_held(off_type off, std::ios_base::seekdir dir, int64_t current) {
+        bool from_end = dir == std::ios_base::end;
+        if (from_end) {
+            holding_ = true;
+        }
+        int64_t target = dir == std::ios_base::beg ? static_cast<int64_t>(off) : current + off;
+        while (from_end || target > offset_ + (egptr() - eback())) {
+            setg(eback(), egptr(), egptr());
+            if (traits_type::eq_int_type(underflow(), traits_type::eof())) {
+                if (!from_end) {
+                    return pos_type(off_type(-1));
+                }
+                break;
+            }
+        }
+        int64_t end = offset_ + (egptr() - eback());
+        if (from_end) {
+            target = end + off;
+        }
+        if (target < offset_ || target > end) {
+            return pos_type(off_type(-1));
+        }
+        setg(eback(), eback() + (target - offset_), egptr());
+        return pos_type(target);
+    }
+
+    OpenVINOStreamReadCallback read_;
+    OpenVINOStreamSeekCallback seek_;
+    uintptr_t handle_;
+    std::vector<char> buffer_;
+    int64_t offset_;  // stream offset of eback()
+    bool holding_;    // keep read data for seeks back on a non-seekable source
+};
+
+// streambuf that pushes data to a caller-provided write callback in chunks.
+// OpenVINO only seeks back to positions it has asked for with tellp, so when
+// the destination cannot seek, the data from the first such position on is
+// held in memory until finish writes it.
+class CallbackWriteBuf : public std::streambuf {
+public:
+    CallbackWriteBuf(OpenVINOStreamWriteCallback write, OpenVINOStreamSeekCallback seek, uintptr_t handle)
+        : write_(write), seek_(seek), handle_(handle), buffer_(kStreamChunkSize), offset_(0), filled_(0), holding_(false) {
+        set_put(0);
+    }
+
+    // Writes all buffered and held data
+    bool finish() {
+        holding_ = false;
+        return flush_buffer();
+    }
+
+protected:
+    int_type overflow(int_type ch) override {
+        if (!flush_buffer()) {
+            return traits_type::eof();
+        }
+        if (pptr() == epptr()) {
+            // Held data stays, so grow the buffer after it
+            size_t pos = put_offset();
+            buffer_.resize(buffer_.size() * 2);
+            set_put(pos);
+        }
+        if (!traits_type::eq_int_type(ch, traits_type::eof())) {
+            *pptr() = traits_type::to_char_type(ch);
+            pbump(1);
+        }
+        return traits_type::not_eof(ch);
+    }
+
+    int sync() override {
+        return flush_buffer() ? 0 : -1;
+    }
+
+    pos_type seekoff(off_type off, std::ios_base::seekdir dir, std::ios_base::openmode) override {
+        int64_t current = offset_ + static_cast<int64_t>(put_offset());
+        if (dir == std::ios_base::cur && off == 0) {
+            if (!seek_) {
+                holding_ = true;
+            }
+            return pos_type(current);
+        }
+        if (!seek_) {
+            return seek_held(off, dir, current);
+        }
+        if (!flush_buffer()) {
+            return pos_type(off_type(-1));
+        }
+        int32_t whence = dir == std::ios_base::beg ? 0 : (dir == std::ios_base::cur ? 1 : 2);
+        int64_t pos = seek_(handle_, off, whence);
+        if (pos < 0) {
+            return pos_type(off_type(-1));
+        }
+        offset_ = pos;
+        return pos_type(pos);
+    }
+
+    pos_type seekpos(pos_type pos, std::ios_base::openmode which) override {
+        return seekoff(off_type(pos), std::ios_base::beg, which);
+    }
+
+private:
+    size_t put_offset() const {
+        return static_cast<size_t>(pptr() - pbase());
+    }
+
+    // Points the put area at the buffer with the put pointer at pos
+    void set_put(size_t pos) {
+        setp(buffer_.data(), buffer_.data() + buffer_.size());
+        while (pos > 0) {
+            int step = static_cast<int>(std::min<size_t>(pos, INT_MAX));
+            pbump(step);
+            pos -= static_cast<size_t>(step);
+        }
+    }
+
+    // Seeks within the buffered data of a non-seekable destination
+    pos_type seek_held(off_type off, std::ios_base::seekdir dir, int64_t current) {
+        filled_ = std::max(filled_, put_offset());
+        int64_t end = offset_ + static_cast<int64_t>(filled_);
+        int64_t target = dir == std::ios_base::beg ? static_cast<int64_t>(off)
+                       : dir == std::ios_base::cur ? current + off : end + off;
+        if (target < offset_ || target > end) {
+            return pos_type(off_type(-1));
+        }
+        set_put(static_cast<size_t>(target - offset_));
+        return pos_type(target);
+    }
+
+    bool flush_buffer() {
+        filled_ = std::max(filled_, put_offset());
+        if (holding_) {
+            return true;
+        }
+        int64_t n = static_cast<int64_t>(filled_);
+        if (n > 0) {
+            if (write_(handle_, buffer_.data(), n) != n) {
+                return false;
+            }
+            offset_ += n;
+        }
+        filled_ = 0;
+        set_put(0);
+        return true;
+    }
+
+    OpenVINOStreamWriteCallback write_;
+    OpenVINOStreamSeekCallback seek_;
+    uintptr_t handle_;
+    std::vector<char> buffer_;
+    int64_t offset_;  // stream offset of the buffer start
+    size_t filled_;   // bytes of the buffer holding data, at least put_offset()
+    bool holding_;    // keep written data for seeks back on a non-seekable destination
+};
+
+// Owns the model copy a PrePostProcessor edits.
+struct PrePostProcessorHandle {
+    std::shared_ptr<ov::Model> model;
+    ov::preprocess::PrePostProcessor ppp;
+
+    explicit PrePostProcessorHandle(std::shared_ptr<ov::Model> m) : model(m), ppp(m) {}
+};
+
+static ov::preprocess::InputInfo& select_input(ov::preprocess::PrePostProcessor& ppp, const char* name, int32_t index) {
+    if (name) {
+        return ppp.input(name);
+    }
+    if (index >= 0) {
+        return ppp.input(static_cast<size_t>(index));
+    }
+    return ppp.input();
+}
+
+static ov::preprocess::OutputInfo& select_output(ov::preprocess::PrePostProcessor& ppp, const char* name, int32_t index) {
+    if (name) {
+        return ppp.output(name);
+    }
+    if (index >= 0) {
+        return ppp.output(static_cast<size_t>(index));
+    }
+    return ppp.output();
+}
+
+static ov::preprocess::ColorFormat get_color_format(int32_t format) {
+    switch (format) {
+        case 0: return ov::preprocess::ColorFormat::NV12_SINGLE_PLANE;
+        case 1: return ov::preprocess::ColorFormat::NV12_TWO_PLANES;
+        case 2: return ov::preprocess::ColorFormat::I420_SINGLE_PLANE;
+        case 3: return ov::preprocess::ColorFormat::I420_THREE_PLANES;
+        case 4: return ov::preprocess::ColorFormat::RGB;
+        case 5: return ov::preprocess::ColorFormat::BGR;
+        case 6: return ov::preprocess::ColorFormat::GRAY;
+        case 7: return ov::preprocess::ColorFormat::RGBX;
+        case 8: return ov::preprocess::ColorFormat::BGRX;
+        default: throw std::invalid_argument("unknown color format " + std::to_string(format));
+    }
+}
+
+static ov::preprocess::ResizeAlgorithm get_resize_algorithm(int32_t algorithm) {
+    switch (algorithm) {
+        case 0: return ov::preprocess::ResizeAlgorithm::RESIZE_LINEAR;
+        case 1: return ov::preprocess::ResizeAlgorithm::RESIZE_CUBIC;
+        case 2: return ov::preprocess::ResizeAlgorithm::RESIZE_NEAREST;
+        case 3: return ov::preprocess::ResizeAlgorithm::RESIZE_BILINEAR_PILLOW;
+        case 4: return ov::preprocess::ResizeAlgorithm::RESIZE_BICUBIC_PILLOW;
+        default: throw std::invalid_argument("unknown resize algorithm " + std::to_string(algorithm));
+    }
+}
+
+// Runs one builder step, converting exceptions into an error code.
+template <typename Step>
+static int32_t preprocessor_step(OpenVINOPrePostProcessor ppp, OpenVINOError* error, Step step) {
+    try {
+        step(reinterpret_cast<PrePostProcessorHandle*>(ppp)->ppp);
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
 }
 
 extern "C" {
@@ -115,6 +613,68 @@ void openvino_core_free_device_list(char** devices, int32_t count) {
     }
 }
 
+int32_t openvino_core_get_property(
+    OpenVINOCore core,
+    const char* device,
+    const char* name,
+    OpenVINOPropertyValue* value,
+    OpenVINOError* error
+) {
+    try {
+        ov::Core* c = reinterpret_cast<ov::Core*>(core);
+        ov::Any any = c->get_property(device, name);
+        fill_property_value(any, value);
+        return 0;
+    } catch (const std::exception& e) {
+        set_device_error_from_exception(error, reinterpret_cast<ov::Core*>(core), device, e);
+        return -1;
+    }
+}
+
+int32_t openvino_core_set_property(
+    OpenVINOCore core,
+    const char* device,
+    const char** property_keys,
+    const char** property_values,
+    int32_t property_count,
+    OpenVINOError* error
+) {
+    try {
+        ov::Core* c = reinterpret_cast<ov::Core*>(core);
+
+        ov::AnyMap config;
+        if (!parse_properties(property_keys, property_values, property_count, config, error)) {
+            return -1;
+        }
+
+        if (device[0] == '\0') {
+            c->set_property(config);
+        } else {
+            c->set_property(device, config);
+        }
+        return 0;
+    } catch (const std::exception& e) {
+        set_device_error_from_exception(error, reinterpret_cast<ov::Core*>(core), device, e);
+        return -1;
+    }
+}
+
+void openvino_property_value_free(OpenVINOPropertyValue* value) {
+    if (value) {
+        if (value->string_value) {
+            free(value->string_value);
+            value->string_value = nullptr;
+        }
+        if (value->list_values) {
+            for (int32_t i = 0; i < value->list_count; i++) {
+                free(value->list_values[i]);
+            }
+            free(value->list_values);
+            value->list_values = nullptr;
+        }
+    }
+}
+
 OpenVINOModel openvino_core_read_model(OpenVINOCore core, const char* model_path, OpenVINOError* error) {
     try {
         ov::Core* c = reinterpret_cast<ov::Core*>(core);
@@ -128,12 +688,76 @@ OpenVINOModel openvino_core_read_model(OpenVINOCore core, const char* model_path
     }
 }
 
+OpenVINOModel openvino_core_read_model_with_weights(
+    OpenVINOCore core,
+    const char* model_path,
+    const char* weights_path,
+    OpenVINOError* error
+) {
+    try {
+        ov::Core* c = reinterpret_cast<ov::Core*>(core);
+        std::shared_ptr<ov::Model>* model = new std::shared_ptr<ov::Model>(
+            c->read_model(model_path, weights_path)
+        );
+        return reinterpret_cast<OpenVINOModel>(model);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return nullptr;
+    }
+}
+
+OpenVINOModel openvino_core_read_model_from_memory(
+    OpenVINOCore core,
+    const char* model_data,
+    int64_t model_size,
+    const void* weights_data,
+    int64_t weights_size,
+    OpenVINOError* error
+) {
+    try {
+        ov::Core* c = reinterpret_cast<ov::Core*>(core);
+        std::string model_str(model_data, static_cast<size_t>(model_size));
+
+        // The model keeps referencing the weights tensor, so the caller's
+        // buffer is copied into memory owned by OpenVINO.
+        ov::Tensor weights;
+        if (weights_data && weights_size > 0) {
+            weights = ov::Tensor(ov::element::u8, ov::Shape{static_cast<size_t>(weights_size)});
+            std::memcpy(weights.data(), weights_data, static_cast<size_t>(weights_size));
+        }
+
+        std::shared_ptr<ov::Model>* model = new std::shared_ptr<ov::Model>(
+            c->read_model(model_str, weights)
+        );
+        return reinterpret_cast<OpenVINOModel>(model);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return nullptr;
+    }
+}
+
 void openvino_model_destroy(OpenVINOModel model) {
     if (model) {
         delete reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
     }
 }
 
+int32_t openvino_model_save(
+    OpenVINOModel model,
+    const char* xml_path,
+    bool compress_to_fp16,
+    OpenVINOError* error
+) {
+    try {
+        std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
+        ov::save_model(*m, xml_path, compress_to_fp16);
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
 OpenVINOCompiledModel openvino_core_compile_model(
     OpenVINOCore core,
     OpenVINOModel model,
@@ -149,7 +773,7 @@ OpenVINOCompiledModel openvino_core_compile_model(
         );
         return reinterpret_cast<OpenVINOCompiledModel>(compiled);
     } catch (const std::exception& e) {
-        set_error_from_exception(error, e);
+        set_device_error_from_exception(error, reinterpret_cast<ov::Core*>(core), device, e);
         return nullptr;
     }
 }
@@ -158,8 +782,8 @@ OpenVINOCompiledModel openvino_core_compile_model_with_properties(
     OpenVINOCore core,
     OpenVINOModel model,
     const char* device,
-    const char* property_keys,
-    const char* property_values,
+    const char** property_keys,
+    const char** property_values,
     int32_t property_count,
     OpenVINOError* error
 ) {
@@ -167,56 +791,9 @@ OpenVINOCompiledModel openvino_core_compile_model_with_properties(
         ov::Core* c = reinterpret_cast<ov::Core*>(core);
         std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
 
-        // Parse property keys and values (comma-separated strings)
-        std::map<std::string, std::string> props;
-        std::istringstream keys_stream(property_keys);
-        std::istringstream values_stream(property_values);
-        std::string key, value;
-        
-        for (int32_t i = 0; i < property_count; i++) {
-            if (!std::getline(keys_stream, key, ',') || !std::getline(values_stream, value, ',')) {
-                set_error(error, -1, "Invalid property format");
-                return nullptr;
-            }
-            // Trim whitespace
-            key.erase(0, key.find_first_not_of(" \t"));
-            key.erase(key.find_last_not_of(" \t") + 1);
-            value.erase(0, value.find_first_not_of(" \t"));
-            value.erase(value.find_last_not_of(" \t") + 1);
-            props[key] = value;
-        }
-
-        // Build ov::AnyMap from properties
         ov::AnyMap config;
-        for (const auto& prop : props) {
-            // Handle PERFORMANCE_HINT as string
-            if (prop.first == "PERFORMANCE_HINT") {
-                config[prop.first] = prop.second; // "LATENCY" or "THROUGHPUT"
-            }
-            // Handle numeric properties
-            else if (prop.first == "INFERENCE_NUM_THREADS" || prop.first == "NUM_STREAMS") {
-                try {
-                    int32_t int_val = std::stoi(prop.second);
-                    config[prop.first] = int_val;
-                } catch (...) {
-                    // If not an integer, use as string
-                    config[prop.first] = prop.second;
-                }
-            }
-            // Handle other properties that might be numeric
-            else if (prop.first.find("STREAM") != std::string::npos || 
-                     prop.first.find("THREAD") != std::string::npos) {
-                try {
-                    int32_t int_val = std::stoi(prop.second);
-                    config[prop.first] = int_val;
-                } catch (...) {
-                    config[prop.first] = prop.second;
-                }
-            }
-            // Default: use as string
-            else {
-                config[prop.first] = prop.second;
-            }
+        if (!parse_properties(property_keys, property_values, property_count, config, error)) {
+            return nullptr;
         }
 
         ov::CompiledModel* compiled = new ov::CompiledModel(
@@ -224,7 +801,7 @@ OpenVINOCompiledModel openvino_core_compile_model_with_properties(
         );
         return reinterpret_cast<OpenVINOCompiledModel>(compiled);
     } catch (const std::exception& e) {
-        set_error_from_exception(error, e);
+        set_device_error_from_exception(error, reinterpret_cast<ov::Core*>(core), device, e);
         return nullptr;
     }
 }
@@ -235,6 +812,23 @@ void openvino_compiled_model_destroy(OpenVINOCompiledModel compiled_model) {
     }
 }
 
+int32_t openvino_compiled_model_get_property(
+    OpenVINOCompiledModel compiled_model,
+    const char* name,
+    OpenVINOPropertyValue* value,
+    OpenVINOError* error
+) {
+    try {
+        ov::CompiledModel* cm = reinterpret_cast<ov::CompiledModel*>(compiled_model);
+        ov::Any any = cm->get_property(name);
+        fill_property_value(any, value);
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
 int32_t openvino_compiled_model_release_memory(
     OpenVINOCompiledModel compiled_model,
     OpenVINOError* error
@@ -249,6 +843,62 @@ int32_t openvino_compiled_model_release_memory(
     }
 }
 
+int32_t openvino_compiled_model_export(
+    OpenVINOCompiledModel compiled_model,
+    OpenVINOStreamWriteCallback write,
+    OpenVINOStreamSeekCallback seek,
+    uintptr_t handle,
+    OpenVINOError* error
+) {
+    try {
+        ov::CompiledModel* cm = reinterpret_cast<ov::CompiledModel*>(compiled_model);
+
+        CallbackWriteBuf buf(write, seek, handle);
+        std::ostream stream(&buf);
+        cm->export_model(stream);
+        stream.flush();
+        if (!stream || !buf.finish()) {
+            set_error(error, OPENVINO_ERROR_GENERAL, "Failed to write exported model");
+            return -1;
+        }
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
+OpenVINOCompiledModel openvino_core_import_model(
+    OpenVINOCore core,
+    OpenVINOStreamReadCallback read,
+    OpenVINOStreamSeekCallback seek,
+    uintptr_t handle,
+    const char* device,
+    const char** property_keys,
+    const char** property_values,
+    int32_t property_count,
+    OpenVINOError* error
+) {
+    try {
+        ov::Core* c = reinterpret_cast<ov::Core*>(core);
+
+        ov::AnyMap config;
+        if (!parse_properties(property_keys, property_values, property_count, config, error)) {
+            return nullptr;
+        }
+
+        CallbackReadBuf buf(read, seek, handle);
+        std::istream stream(&buf);
+        ov::CompiledModel* compiled = new ov::CompiledModel(
+            c->import_model(stream, device, config)
+        );
+        return reinterpret_cast<OpenVINOCompiledModel>(compiled);
+    } catch (const std::exception& e) {
+        set_device_error_from_exception(error, reinterpret_cast<ov::Core*>(core), device, e);
+        return nullptr;
+    }
+}
+
 OpenVINOInferRequest openvino_compiled_model_create_infer_request(
     OpenVINOCompiledModel compiled_model,
     OpenVINOError* error
@@ -276,7 +926,7 @@ int32_t openvino_infer_request_set_input_tensor(
     OpenVINOInferRequest request,
     const char* name,
     const void* data,
-    int32_t* shape,
+    const int64_t* shape,
     int32_t shape_size,
     int32_t data_type,
     OpenVINOError* error
@@ -291,11 +941,8 @@ int32_t openvino_infer_request_set_input_tensor(
 
         ov::element::Type element_type = get_element_type(data_type);
 
-        size_t total_elements = calculate_total_elements(shape, shape_size);
-        size_t data_size = total_elements * element_type.size();
-
         ov::Tensor tensor(element_type, ov_shape);
-        std::memcpy(tensor.data(), data, data_size);
+        copy_host_data(tensor, data);
 
         req->set_tensor(name, tensor);
 
@@ -310,7 +957,7 @@ int32_t openvino_infer_request_set_input_tensor_by_index(
     OpenVINOInferRequest request,
     int32_t index,
     const void* data,
-    int32_t* shape,
+    const int64_t* shape,
     int32_t shape_size,
     int32_t data_type,
     OpenVINOError* error
@@ -325,11 +972,8 @@ int32_t openvino_infer_request_set_input_tensor_by_index(
 
         ov::element::Type element_type = get_element_type(data_type);
 
-        size_t total_elements = calculate_total_elements(shape, shape_size);
-        size_t data_size = total_elements * element_type.size();
-
         ov::Tensor tensor(element_type, ov_shape);
-        std::memcpy(tensor.data(), data, data_size);
+        copy_host_data(tensor, data);
 
         req->set_input_tensor(static_cast<size_t>(index), tensor);
 
@@ -398,7 +1042,7 @@ int32_t openvino_infer_request_set_tensors_by_index(
         // Get the input port by index
         auto inputs = req->get_compiled_model().inputs();
         if (index < 0 || static_cast<size_t>(index) >= inputs.size()) {
-            set_error(error, -1, "Invalid input index");
+            set_error(error, OPENVINO_ERROR_NOT_FOUND, "Invalid input index");
             return -1;
         }
         
@@ -429,6 +1073,24 @@ int32_t openvino_infer_request_set_output_tensor(
     }
 }
 
+int32_t openvino_infer_request_set_input_tensor_unified_by_index(
+    OpenVINOInferRequest request,
+    int32_t index,
+    OpenVINOTensor tensor,
+    OpenVINOError* error
+) {
+    try {
+        ov::InferRequest* req = reinterpret_cast<ov::InferRequest*>(request);
+        ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
+
+        req->set_input_tensor(static_cast<size_t>(index), *t);
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
 int32_t openvino_infer_request_set_output_tensor_by_index(
     OpenVINOInferRequest request,
     int32_t index,
@@ -560,66 +1222,36 @@ void openvino_profiling_info_free(OpenVINOProfilingInfo* info, int32_t count) {
     free(info);
 }
 
-struct CallbackData {
-    OpenVINOCallback callback;
-    void* user_data;
-};
-
-static std::map<OpenVINOInferRequest, CallbackData*> callback_registry;
-static std::mutex callback_mutex;
-
 int32_t openvino_infer_request_set_callback(
     OpenVINOInferRequest request,
     OpenVINOCallback callback,
-    void* user_data,
+    uintptr_t handle,
     OpenVINOError* error
 ) {
     try {
         ov::InferRequest* req = reinterpret_cast<ov::InferRequest*>(request);
-        
-        std::lock_guard<std::mutex> lock(callback_mutex);
-        
-        auto it = callback_registry.find(request);
-        if (it != callback_registry.end()) {
-            delete it->second;
-            callback_registry.erase(it);
-        }
-        
         if (callback == nullptr) {
             req->set_callback([](std::exception_ptr) {});
             return 0;
         }
-        
-        CallbackData* cb_data = new CallbackData{callback, user_data};
-        callback_registry[request] = cb_data;
-        req->set_callback([cb_data](std::exception_ptr eptr) {
-            int32_t has_error = 0;
-            char* error_msg = nullptr;
-            char* error_msg_copy = nullptr;
-            
+        // The callback stays installed, and fires after every inference,
+        // until it is replaced or the request is destroyed.
+        req->set_callback([callback, handle](std::exception_ptr eptr) {
+            int32_t error_code = 0;
+            std::string msg;
             if (eptr) {
-                has_error = 1;
                 try {
                     std::rethrow_exception(eptr);
                 } catch (const std::exception& e) {
-                    std::string msg = e.what();
-                    error_msg_copy = strdup(msg.c_str());
-                    error_msg = error_msg_copy;
+                    error_code = classify_exception(e);
+                    msg = e.what();
                 } catch (...) {
-                    error_msg_copy = strdup("Unknown exception");
-                    error_msg = error_msg_copy;
+                    error_code = OPENVINO_ERROR_GENERAL;
+                    msg = "Unknown exception";
                 }
             }
-            
-            if (cb_data && cb_data->callback) {
-                cb_data->callback(cb_data->user_data, has_error, error_msg);
-            }
-            
-            if (error_msg_copy) {
-                free(error_msg_copy);
-            }
+            callback(handle, error_code, error_code != 0 ? const_cast<char*>(msg.c_str()) : nullptr);
         });
-        
         return 0;
     } catch (const std::exception& e) {
         set_error_from_exception(error, e);
@@ -629,15 +1261,7 @@ int32_t openvino_infer_request_set_callback(
 
 void openvino_infer_request_clear_callback(OpenVINOInferRequest request) {
     try {
-        ov::InferRequest* req = reinterpret_cast<ov::InferRequest*>(request);
-        req->set_callback([](std::exception_ptr) {});
-        
-        std::lock_guard<std::mutex> lock(callback_mutex);
-        auto it = callback_registry.find(request);
-        if (it != callback_registry.end()) {
-            delete it->second;
-            callback_registry.erase(it);
-        }
+        reinterpret_cast<ov::InferRequest*>(request)->set_callback([](std::exception_ptr) {});
     } catch (...) {
     }
 }
@@ -745,53 +1369,108 @@ OpenVINOTensor openvino_infer_request_get_output_tensor_by_index(
     }
 }
 
-void* openvino_tensor_get_data(OpenVINOTensor tensor, int32_t* data_type, OpenVINOError* error) {
+void* openvino_tensor_get_data(OpenVINOTensor tensor, int32_t* data_type, OpenVINOError* error) {
+    try {
+        ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
+
+        *data_type = element_type_to_int32(t->get_element_type());
+        return t->data();
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return nullptr;
+    }
+}
+
+int64_t* openvino_tensor_get_shape(OpenVINOTensor tensor, int32_t* shape_size, OpenVINOError* error) {
+    try {
+        ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
+        ov::Shape shape = t->get_shape();
+
+        *shape_size = static_cast<int32_t>(shape.size());
+        int64_t* result = static_cast<int64_t*>(malloc(sizeof(int64_t) * (shape.empty() ? 1 : shape.size())));
+
+        for (size_t i = 0; i < shape.size(); i++) {
+            result[i] = static_cast<int64_t>(shape[i]);
+        }
+
+        return result;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        *shape_size = 0;
+        return nullptr;
+    }
+}
+
+void openvino_tensor_free_shape(int64_t* shape) {
+    if (shape) {
+        free(shape);
+    }
+}
+
+int64_t* openvino_tensor_get_strides(OpenVINOTensor tensor, int32_t* rank, OpenVINOError* error) {
     try {
         ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
+        ov::Strides strides = t->get_strides();
 
-        ov::element::Type element_type = t->get_element_type();
-        if (element_type == ov::element::f32) {
-            *data_type = 0; // float32
-        } else if (element_type == ov::element::i64) {
-            *data_type = 1; // int64
-        } else if (element_type == ov::element::i32) {
-            *data_type = 2; // int32
-        } else if (element_type == ov::element::u8) {
-            *data_type = 3; // uint8
-        } else {
-            *data_type = 0; // Default to float32
+        *rank = static_cast<int32_t>(strides.size());
+        int64_t* result = static_cast<int64_t*>(malloc(sizeof(int64_t) * (strides.empty() ? 1 : strides.size())));
+
+        for (size_t i = 0; i < strides.size(); i++) {
+            result[i] = static_cast<int64_t>(strides[i]);
         }
 
-        return t->data();
+        return result;
     } catch (const std::exception& e) {
         set_error_from_exception(error, e);
+        *rank = 0;
         return nullptr;
     }
 }
 
-int32_t* openvino_tensor_get_shape(OpenVINOTensor tensor, int32_t* shape_size, OpenVINOError* error) {
+int32_t openvino_tensor_is_continuous(OpenVINOTensor tensor, OpenVINOError* error) {
     try {
         ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
-        ov::Shape shape = t->get_shape();
+        return t->is_continuous() ? 1 : 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
 
-        *shape_size = static_cast<int32_t>(shape.size());
-        int32_t* result = static_cast<int32_t*>(malloc(sizeof(int32_t) * shape.size()));
+OpenVINOTensor openvino_tensor_new_roi(
+    OpenVINOTensor tensor,
+    const int64_t* begin,
+    const int64_t* end,
+    int32_t rank,
+    OpenVINOError* error
+) {
+    try {
+        ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
 
-        for (size_t i = 0; i < shape.size(); i++) {
-            result[i] = static_cast<int32_t>(shape[i]);
+        ov::Coordinate roi_begin, roi_end;
+        for (int32_t i = 0; i < rank; i++) {
+            roi_begin.push_back(static_cast<size_t>(begin[i]));
+            roi_end.push_back(static_cast<size_t>(end[i]));
         }
 
-        return result;
+        // The region shares the memory of t and keeps it alive.
+        ov::Tensor* roi = new ov::Tensor(*t, roi_begin, roi_end);
+        return reinterpret_cast<OpenVINOTensor>(roi);
     } catch (const std::exception& e) {
         set_error_from_exception(error, e);
-        *shape_size = 0;
         return nullptr;
     }
 }
 
-void openvino_tensor_free_shape(int32_t* shape) {
-    if (shape) {
-        free(shape);
+int32_t openvino_tensor_copy_to(OpenVINOTensor src, OpenVINOTensor dst, OpenVINOError* error) {
+    try {
+        ov::Tensor* s = reinterpret_cast<ov::Tensor*>(src);
+        ov::Tensor* d = reinterpret_cast<ov::Tensor*>(dst);
+        s->copy_to(*d);
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
     }
 }
 
@@ -803,7 +1482,7 @@ void openvino_tensor_destroy(OpenVINOTensor tensor) {
 
 OpenVINOTensor openvino_tensor_new(
     int32_t data_type,
-    int32_t* shape,
+    const int64_t* shape,
     int32_t shape_size,
     OpenVINOError* error
 ) {
@@ -825,7 +1504,7 @@ OpenVINOTensor openvino_tensor_new(
 
 OpenVINOTensor openvino_tensor_new_with_data(
     int32_t data_type,
-    int32_t* shape,
+    const int64_t* shape,
     int32_t shape_size,
     const void* data,
     OpenVINOError* error
@@ -838,12 +1517,37 @@ OpenVINOTensor openvino_tensor_new_with_data(
             ov_shape.push_back(static_cast<size_t>(shape[i]));
         }
         
-        size_t total_elements = calculate_total_elements(shape, shape_size);
-        size_t data_size = total_elements * element_type.size();
-        
         ov::Tensor* tensor = new ov::Tensor(element_type, ov_shape);
-        std::memcpy(tensor->data(), data, data_size);
+        try {
+            copy_host_data(*tensor, data);
+        } catch (...) {
+            delete tensor;
+            throw;
+        }
+        
+        return reinterpret_cast<OpenVINOTensor>(tensor);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return nullptr;
+    }
+}
+
+OpenVINOTensor openvino_tensor_new_from_host_ptr(
+    int32_t data_type,
+    const int64_t* shape,
+    int32_t shape_size,
+    void* data,
+    OpenVINOError* error
+) {
+    try {
+        ov::element::Type element_type = get_element_type(data_type);
+        
+        ov::Shape ov_shape;
+        for (int32_t i = 0; i < shape_size; i++) {
+            ov_shape.push_back(static_cast<size_t>(shape[i]));
+        }
         
+        ov::Tensor* tensor = new ov::Tensor(element_type, ov_shape, data);
         return reinterpret_cast<OpenVINOTensor>(tensor);
     } catch (const std::exception& e) {
         set_error_from_exception(error, e);
@@ -882,7 +1586,66 @@ int32_t openvino_tensor_get_element_type(OpenVINOTensor tensor, OpenVINOError* e
     }
 }
 
-int32_t openvino_tensor_set_shape(OpenVINOTensor tensor, int32_t* shape, int32_t shape_size, OpenVINOError* error) {
+int32_t openvino_tensor_set_strings(
+    OpenVINOTensor tensor,
+    const char* data,
+    const int64_t* offsets,
+    int64_t count,
+    OpenVINOError* error
+) {
+    try {
+        ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
+        if (t->get_element_type() != ov::element::string) {
+            throw std::invalid_argument("tensor does not hold strings");
+        }
+        if (!t->is_continuous()) {
+            throw std::invalid_argument("string tensor is not contiguous");
+        }
+        if (static_cast<size_t>(count) != t->get_size()) {
+            throw std::invalid_argument("string count does not match the tensor size");
+        }
+        std::string* strings = t->data<std::string>();
+        for (int64_t i = 0; i < count; i++) {
+            strings[i].assign(data + offsets[i], static_cast<size_t>(offsets[i + 1] - offsets[i]));
+        }
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
+int32_t openvino_tensor_get_strings(
+    OpenVINOTensor tensor,
+    const char** data,
+    int64_t* lengths,
+    int64_t count,
+    OpenVINOError* error
+) {
+    try {
+        ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
+        if (t->get_element_type() != ov::element::string) {
+            throw std::invalid_argument("tensor does not hold strings");
+        }
+        if (!t->is_continuous()) {
+            throw std::invalid_argument("string tensor is not contiguous");
+        }
+        if (static_cast<size_t>(count) != t->get_size()) {
+            throw std::invalid_argument("string count does not match the tensor size");
+        }
+        const std::string* strings = t->data<std::string>();
+        for (int64_t i = 0; i < count; i++) {
+            data[i] = strings[i].data();
+            lengths[i] = static_cast<int64_t>(strings[i].size());
+        }
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
+int32_t openvino_tensor_set_shape(OpenVINOTensor tensor, const int64_t* shape, int32_t shape_size, OpenVINOError* error) {
     try {
         ov::Tensor* t = reinterpret_cast<ov::Tensor*>(tensor);
         
@@ -1032,36 +1795,48 @@ void openvino_variable_state_free_name(const char* name) {
     }
 }
 
-// Helper: fill shape from PartialShape; use -1 for dynamic dimensions
-static void fill_shape_from_partial(ov::PartialShape ps, int32_t* shape_out, int32_t* shape_size) {
-    *shape_size = static_cast<int32_t>(ps.size());
-    for (size_t j = 0; j < ps.size(); j++) {
+// Helper: fill per-dimension bounds from PartialShape; -1 max means unbounded
+static void fill_port_shape(const ov::PartialShape& ps, OpenVINOPortInfo* port) {
+    if (ps.rank().is_dynamic()) {
+        port->shape_size = -1;
+        port->shape_min = nullptr;
+        port->shape_max = nullptr;
+        return;
+    }
+    size_t rank = ps.size();
+    port->shape_size = static_cast<int32_t>(rank);
+    port->shape_min = static_cast<int64_t*>(malloc(sizeof(int64_t) * (rank == 0 ? 1 : rank)));
+    port->shape_max = static_cast<int64_t*>(malloc(sizeof(int64_t) * (rank == 0 ? 1 : rank)));
+    for (size_t j = 0; j < rank; j++) {
         const ov::Dimension& d = ps[j];
-        shape_out[j] = d.is_dynamic() ? -1 : static_cast<int32_t>(d.get_length());
+        port->shape_min[j] = d.get_min_length();
+        port->shape_max[j] = d.get_max_length();
+    }
+}
+
+template <typename Port>
+static OpenVINOPortInfo* make_port_info(const std::vector<Port>& ports, int32_t* count) {
+    *count = static_cast<int32_t>(ports.size());
+    OpenVINOPortInfo* result = static_cast<OpenVINOPortInfo*>(
+        calloc(ports.empty() ? 1 : ports.size(), sizeof(OpenVINOPortInfo))
+    );
+
+    for (size_t i = 0; i < ports.size(); i++) {
+        const auto& port = ports[i];
+        result[i].name = strdup(port.get_any_name().c_str());
+        fill_port_shape(port.get_partial_shape(), &result[i]);
+        result[i].data_type = element_type_to_int32(port.get_element_type());
+        ov::Layout layout = ov::layout::get_layout(port);
+        result[i].layout = strdup(layout.empty() ? "" : layout.to_string().c_str());
     }
+
+    return result;
 }
 
 OpenVINOPortInfo* openvino_model_get_inputs(OpenVINOModel model, int32_t* count, OpenVINOError* error) {
     try {
         std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
-        const auto& inputs = (*m)->inputs();
-        
-        *count = static_cast<int32_t>(inputs.size());
-        OpenVINOPortInfo* result = static_cast<OpenVINOPortInfo*>(malloc(sizeof(OpenVINOPortInfo) * inputs.size()));
-        
-        for (size_t i = 0; i < inputs.size(); i++) {
-            const auto& input = inputs[i];
-            result[i].name = strdup(input.get_any_name().c_str());
-            
-            ov::PartialShape ps = input.get_partial_shape();
-            result[i].shape_size = static_cast<int32_t>(ps.size());
-            result[i].shape = static_cast<int32_t*>(malloc(sizeof(int32_t) * ps.size()));
-            fill_shape_from_partial(ps, result[i].shape, &result[i].shape_size);
-            
-            result[i].data_type = element_type_to_int32(input.get_element_type());
-        }
-        
-        return result;
+        return make_port_info((*m)->inputs(), count);
     } catch (const std::exception& e) {
         set_error_from_exception(error, e);
         *count = 0;
@@ -1072,24 +1847,51 @@ OpenVINOPortInfo* openvino_model_get_inputs(OpenVINOModel model, int32_t* count,
 OpenVINOPortInfo* openvino_model_get_outputs(OpenVINOModel model, int32_t* count, OpenVINOError* error) {
     try {
         std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
-        const auto& outputs = (*m)->outputs();
-        
-        *count = static_cast<int32_t>(outputs.size());
-        OpenVINOPortInfo* result = static_cast<OpenVINOPortInfo*>(malloc(sizeof(OpenVINOPortInfo) * outputs.size()));
-        
-        for (size_t i = 0; i < outputs.size(); i++) {
-            const auto& output = outputs[i];
-            result[i].name = strdup(output.get_any_name().c_str());
-            
-            ov::PartialShape ps = output.get_partial_shape();
-            result[i].shape_size = static_cast<int32_t>(ps.size());
-            result[i].shape = static_cast<int32_t*>(malloc(sizeof(int32_t) * ps.size()));
-            fill_shape_from_partial(ps, result[i].shape, &result[i].shape_size);
-            
-            result[i].data_type = element_type_to_int32(output.get_element_type());
-        }
-        
-        return result;
+        return make_port_info((*m)->outputs(), count);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        *count = 0;
+        return nullptr;
+    }
+}
+
+OpenVINOPortInfo* openvino_compiled_model_get_inputs(OpenVINOCompiledModel compiled_model, int32_t* count, OpenVINOError* error) {
+    try {
+        ov::CompiledModel* cm = reinterpret_cast<ov::CompiledModel*>(compiled_model);
+        return make_port_info(cm->inputs(), count);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        *count = 0;
+        return nullptr;
+    }
+}
+
+OpenVINOPortInfo* openvino_compiled_model_get_outputs(OpenVINOCompiledModel compiled_model, int32_t* count, OpenVINOError* error) {
+    try {
+        ov::CompiledModel* cm = reinterpret_cast<ov::CompiledModel*>(compiled_model);
+        return make_port_info(cm->outputs(), count);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        *count = 0;
+        return nullptr;
+    }
+}
+
+OpenVINOPortInfo* openvino_infer_request_get_inputs(OpenVINOInferRequest request, int32_t* count, OpenVINOError* error) {
+    try {
+        ov::InferRequest* req = reinterpret_cast<ov::InferRequest*>(request);
+        return make_port_info(req->get_compiled_model().inputs(), count);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        *count = 0;
+        return nullptr;
+    }
+}
+
+OpenVINOPortInfo* openvino_infer_request_get_outputs(OpenVINOInferRequest request, int32_t* count, OpenVINOError* error) {
+    try {
+        ov::InferRequest* req = reinterpret_cast<ov::InferRequest*>(request);
+        return make_port_info(req->get_compiled_model().outputs(), count);
     } catch (const std::exception& e) {
         set_error_from_exception(error, e);
         *count = 0;
@@ -1103,12 +1905,266 @@ void openvino_model_free_port_info(OpenVINOPortInfo* ports, int32_t count) {
             if (ports[i].name) {
                 free(ports[i].name);
             }
-            if (ports[i].shape) {
-                free(ports[i].shape);
+            if (ports[i].shape_min) {
+                free(ports[i].shape_min);
+            }
+            if (ports[i].shape_max) {
+                free(ports[i].shape_max);
+            }
+            if (ports[i].layout) {
+                free(ports[i].layout);
             }
         }
         free(ports);
     }
 }
 
+int32_t openvino_model_reshape(
+    OpenVINOModel model,
+    const char** names,
+    const int32_t* indices,
+    const int32_t* ranks,
+    const int64_t* dims_min,
+    const int64_t* dims_max,
+    int32_t port_count,
+    OpenVINOError* error
+) {
+    try {
+        std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
+
+        std::map<std::string, ov::PartialShape> by_name;
+        std::map<size_t, ov::PartialShape> by_index;
+        size_t offset = 0;
+        for (int32_t i = 0; i < port_count; i++) {
+            std::vector<ov::Dimension> dims;
+            for (int32_t j = 0; j < ranks[i]; j++, offset++) {
+                int64_t lo = dims_min[offset];
+                int64_t hi = dims_max[offset];
+                dims.push_back(lo == hi ? ov::Dimension(lo) : ov::Dimension(lo, hi));
+            }
+            if (names) {
+                by_name[names[i]] = ov::PartialShape(dims);
+            } else {
+                by_index[static_cast<size_t>(indices[i])] = ov::PartialShape(dims);
+            }
+        }
+
+        if (names) {
+            (*m)->reshape(by_name);
+        } else {
+            (*m)->reshape(by_index);
+        }
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
+int32_t openvino_model_set_layout(OpenVINOModel model, const char* name, const char* layout, OpenVINOError* error) {
+    try {
+        std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
+        for (const auto& port : (*m)->inputs()) {
+            if (port.get_names().count(name)) {
+                ov::layout::set_layout(port, ov::Layout(layout));
+                return 0;
+            }
+        }
+        for (const auto& port : (*m)->outputs()) {
+            if (port.get_names().count(name)) {
+                ov::layout::set_layout(port, ov::Layout(layout));
+                return 0;
+            }
+        }
+        throw std::out_of_range(std::string("model has no port named '") + name + "'");
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
+int32_t openvino_model_get_batch(OpenVINOModel model, int64_t* batch_min, int64_t* batch_max, OpenVINOError* error) {
+    try {
+        std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
+        ov::Dimension batch = ov::get_batch(*m);
+        *batch_min = batch.get_min_length();
+        *batch_max = batch.get_max_length();
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
+int32_t openvino_model_set_batch(OpenVINOModel model, int64_t batch_min, int64_t batch_max, OpenVINOError* error) {
+    try {
+        std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
+        ov::set_batch(*m, batch_min == batch_max ? ov::Dimension(batch_min) : ov::Dimension(batch_min, batch_max));
+        return 0;
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return -1;
+    }
+}
+
+OpenVINOPrePostProcessor openvino_preprocessor_new(OpenVINOModel model, OpenVINOError* error) {
+    try {
+        std::shared_ptr<ov::Model>* m = reinterpret_cast<std::shared_ptr<ov::Model>*>(model);
+        PrePostProcessorHandle* handle = new PrePostProcessorHandle((*m)->clone());
+        return reinterpret_cast<OpenVINOPrePostProcessor>(handle);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return nullptr;
+    }
+}
+
+void openvino_preprocessor_destroy(OpenVINOPrePostProcessor ppp) {
+    if (ppp) {
+        delete reinterpret_cast<PrePostProcessorHandle*>(ppp);
+    }
+}
+
+OpenVINOModel openvino_preprocessor_build(OpenVINOPrePostProcessor ppp, OpenVINOError* error) {
+    try {
+        PrePostProcessorHandle* handle = reinterpret_cast<PrePostProcessorHandle*>(ppp);
+        std::shared_ptr<ov::Model>* model = new std::shared_ptr<ov::Model>(handle->ppp.build());
+        return reinterpret_cast<OpenVINOModel>(model);
+    } catch (const std::exception& e) {
+        set_error_from_exception(error, e);
+        return nullptr;
+    }
+}
+
+int32_t openvino_preprocessor_input_tensor_set_element_type(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t data_type, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_input(p, name, index).tensor().set_element_type(get_element_type(data_type));
+    });
+}
+
+int32_t openvino_preprocessor_input_tensor_set_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_input(p, name, index).tensor().set_layout(ov::Layout(layout));
+    });
+}
+
+int32_t openvino_preprocessor_input_tensor_set_color_format(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t color_format, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_input(p, name, index).tensor().set_color_format(get_color_format(color_format));
+    });
+}
+
+int32_t openvino_preprocessor_input_tensor_set_spatial_shape(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int64_t height, int64_t width, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        auto& tensor = select_input(p, name, index).tensor();
+        if (height < 0 || width < 0) {
+            tensor.set_spatial_dynamic_shape();
+        } else {
+            tensor.set_spatial_static_shape(static_cast<size_t>(height), static_cast<size_t>(width));
+        }
+    });
+}
+
+int32_t openvino_preprocessor_input_convert_element_type(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t data_type, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_input(p, name, index).preprocess().convert_element_type(get_element_type(data_type));
+    });
+}
+
+int32_t openvino_preprocessor_input_convert_color(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t color_format, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_input(p, name, index).preprocess().convert_color(get_color_format(color_format));
+    });
+}
+
+int32_t openvino_preprocessor_input_resize(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t algorithm, int64_t height, int64_t width, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        auto& steps = select_input(p, name, index).preprocess();
+        if (height < 0 || width < 0) {
+            steps.resize(get_resize_algorithm(algorithm));
+        } else {
+            steps.resize(get_resize_algorithm(algorithm), static_cast<size_t>(height), static_cast<size_t>(width));
+        }
+    });
+}
+
+int32_t openvino_preprocessor_input_mean(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const float* values, int32_t count, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        auto& steps = select_input(p, name, index).preprocess();
+        if (count == 1) {
+            steps.mean(values[0]);
+        } else {
+            steps.mean(std::vector<float>(values, values + count));
+        }
+    });
+}
+
+int32_t openvino_preprocessor_input_scale(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const float* values, int32_t count, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        auto& steps = select_input(p, name, index).preprocess();
+        if (count == 1) {
+            steps.scale(values[0]);
+        } else {
+            steps.scale(std::vector<float>(values, values + count));
+        }
+    });
+}
+
+int32_t openvino_preprocessor_input_convert_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        auto& steps = select_input(p, name, index).preprocess();
+        if (layout == nullptr || layout[0] == '\0') {
+            steps.convert_layout();
+        } else {
+            steps.convert_layout(ov::Layout(layout));
+        }
+    });
+}
+
+int32_t openvino_preprocessor_input_reverse_channels(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_input(p, name, index).preprocess().reverse_channels();
+    });
+}
+
+int32_t openvino_preprocessor_input_model_set_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_input(p, name, index).model().set_layout(ov::Layout(layout));
+    });
+}
+
+int32_t openvino_preprocessor_output_tensor_set_element_type(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t data_type, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_output(p, name, index).tensor().set_element_type(get_element_type(data_type));
+    });
+}
+
+int32_t openvino_preprocessor_output_tensor_set_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_output(p, name, index).tensor().set_layout(ov::Layout(layout));
+    });
+}
+
+int32_t openvino_preprocessor_output_convert_element_type(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t data_type, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_output(p, name, index).postprocess().convert_element_type(get_element_type(data_type));
+    });
+}
+
+int32_t openvino_preprocessor_output_convert_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        auto& steps = select_output(p, name, index).postprocess();
+        if (layout == nullptr || layout[0] == '\0') {
+            steps.convert_layout();
+        } else {
+            steps.convert_layout(ov::Layout(layout));
+        }
+    });
+}
+
+int32_t openvino_preprocessor_output_model_set_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error) {
+    return preprocessor_step(ppp, error, [&](ov::preprocess::PrePostProcessor& p) {
+        select_output(p, name, index).model().set_layout(ov::Layout(layout));
+    });
+}
+
 } // extern "C"
@@ -18,6 +18,7 @@ typedef struct openvino_compiled_model* OpenVINOCompiledModel;
 typedef struct openvino_infer_request* OpenVINOInferRequest;
 typedef struct openvino_tensor* OpenVINOTensor;
 typedef struct openvino_variable_state* OpenVINOVariableState;
+typedef struct openvino_preprocessor* OpenVINOPrePostProcessor;
 
 // Error handling
 typedef struct {
@@ -25,6 +26,15 @@ typedef struct {
     char* message;
 } OpenVINOError;
 
+// Error codes reported in OpenVINOError.code, classified from the exception
+// OpenVINO raised.
+#define OPENVINO_ERROR_GENERAL            -1
+#define OPENVINO_ERROR_NOT_FOUND          -2
+#define OPENVINO_ERROR_NOT_IMPLEMENTED    -3
+#define OPENVINO_ERROR_PARAMETER_MISMATCH -4
+#define OPENVINO_ERROR_BUSY               -5
+#define OPENVINO_ERROR_CANCELLED          -6
+
 // Core API
 OpenVINOCore openvino_core_create(OpenVINOError* error);
 void openvino_core_destroy(OpenVINOCore core);
@@ -33,10 +43,59 @@ void openvino_core_destroy(OpenVINOCore core);
 char** openvino_core_get_available_devices(OpenVINOCore core, int32_t* count, OpenVINOError* error);
 void openvino_core_free_device_list(char** devices, int32_t count);
 
+// Properties
+typedef struct {
+    int32_t kind;          // 0=string, 1=int, 2=bool, 3=string list, 4=float
+    char* string_value;    // Always set: the value as rendered by OpenVINO
+    int64_t int_value;     // Set for int and bool (1/0) kinds
+    double float_value;    // Set for float kind
+    char** list_values;    // Set for string list kind
+    int32_t list_count;
+} OpenVINOPropertyValue;
+
+int32_t openvino_core_get_property(
+    OpenVINOCore core,
+    const char* device,  // Empty string for Core-wide properties
+    const char* name,
+    OpenVINOPropertyValue* value,
+    OpenVINOError* error
+);
+int32_t openvino_core_set_property(
+    OpenVINOCore core,
+    const char* device,  // Empty string for Core-wide properties
+    const char** property_keys,
+    const char** property_values,
+    int32_t property_count,
+    OpenVINOError* error
+);
+void openvino_property_value_free(OpenVINOPropertyValue* value);
+
 // Model loading
 OpenVINOModel openvino_core_read_model(OpenVINOCore core, const char* model_path, OpenVINOError* error);
+OpenVINOModel openvino_core_read_model_with_weights(
+    OpenVINOCore core,
+    const char* model_path,
+    const char* weights_path,
+    OpenVINOError* error
+);
+OpenVINOModel openvino_core_read_model_from_memory(
+    OpenVINOCore core,
+    const char* model_data,
+    int64_t model_size,
+    const void* weights_data,  // NULL for formats without separate weights
+    int64_t weights_size,
+    OpenVINOError* error
+);
 void openvino_model_destroy(OpenVINOModel model);
 
+// Model serialization to OpenVINO IR (.xml + .bin)
+int32_t openvino_model_save(
+    OpenVINOModel model,
+    const char* xml_path,
+    bool compress_to_fp16,
+    OpenVINOError* error
+);
+
 // Model compilation
 OpenVINOCompiledModel openvino_core_compile_model(
     OpenVINOCore core,
@@ -48,12 +107,46 @@ OpenVINOCompiledModel openvino_core_compile_model_with_properties(
     OpenVINOCore core,
     OpenVINOModel model,
     const char* device,
-    const char* property_keys,
-    const char* property_values,
+    const char** property_keys,
+    const char** property_values,
     int32_t property_count,
     OpenVINOError* error
 );
 void openvino_compiled_model_destroy(OpenVINOCompiledModel compiled_model);
+int32_t openvino_compiled_model_get_property(
+    OpenVINOCompiledModel compiled_model,
+    const char* name,
+    OpenVINOPropertyValue* value,
+    OpenVINOError* error
+);
+
+// Compiled model export/import through caller-provided streams.
+// Callbacks receive the opaque handle passed to the export/import call.
+// Read returns the number of bytes read, 0 at end of stream, or -1 on error.
+// Write returns the number of bytes written, or -1 on error.
+// Seek uses whence 0=start, 1=current, 2=end and returns the new offset, or -1 on error.
+typedef int64_t (*OpenVINOStreamReadCallback)(uintptr_t handle, void* buffer, int64_t size);
+typedef int64_t (*OpenVINOStreamWriteCallback)(uintptr_t handle, void* data, int64_t size);
+typedef int64_t (*OpenVINOStreamSeekCallback)(uintptr_t handle, int64_t offset, int32_t whence);
+
+int32_t openvino_compiled_model_export(
+    OpenVINOCompiledModel compiled_model,
+    OpenVINOStreamWriteCallback write,
+    OpenVINOStreamSeekCallback seek,  // NULL if the destination is not seekable
+    uintptr_t handle,
+    OpenVINOError* error
+);
+OpenVINOCompiledModel openvino_core_import_model(
+    OpenVINOCore core,
+    OpenVINOStreamReadCallback read,
+    OpenVINOStreamSeekCallback seek,  // NULL if the source is not seekable
+    uintptr_t handle,
+    const char* device,
+    const char** property_keys,
+    const char** property_values,
+    int32_t property_count,
+    OpenVINOError* error
+);
 
 // Memory management
 int32_t openvino_compiled_model_release_memory(
@@ -73,9 +166,9 @@ int32_t openvino_infer_request_set_input_tensor(
     OpenVINOInferRequest request,
     const char* name,
     const void* data,
-    int32_t* shape,
+    const int64_t* shape,
     int32_t shape_size,
-    int32_t data_type,  // 0=float32, 1=int64, 2=int32, 3=uint8
+    int32_t data_type,  // DataType code, see internal/cgo/types.go
     OpenVINOError* error
 );
 
@@ -83,7 +176,7 @@ int32_t openvino_infer_request_set_input_tensor_by_index(
     OpenVINOInferRequest request,
     int32_t index,
     const void* data,
-    int32_t* shape,
+    const int64_t* shape,
     int32_t shape_size,
     int32_t data_type,
     OpenVINOError* error
@@ -161,12 +254,23 @@ int32_t openvino_infer_request_set_tensor_unified(
     OpenVINOError* error
 );
 
-typedef void (*OpenVINOCallback)(void* user_data, int32_t has_error, char* error_msg);
+int32_t openvino_infer_request_set_input_tensor_unified_by_index(
+    OpenVINOInferRequest request,
+    int32_t index,
+    OpenVINOTensor tensor,
+    OpenVINOError* error
+);
+
+// Completion callbacks receive the opaque handle passed to set_callback and
+// fire after every inference until replaced, cleared or the request is
+// destroyed. error_code is 0 on success, otherwise one of the
+// OPENVINO_ERROR_* codes; error_msg is only valid during the call.
+typedef void (*OpenVINOCallback)(uintptr_t handle, int32_t error_code, char* error_msg);
 
 int32_t openvino_infer_request_set_callback(
     OpenVINOInferRequest request,
     OpenVINOCallback callback,
-    void* user_data,
+    uintptr_t handle,
     OpenVINOError* error
 );
 
@@ -200,44 +304,128 @@ OpenVINOTensor openvino_infer_request_get_output_tensor_by_index(
 
 // Tensor operations
 void* openvino_tensor_get_data(OpenVINOTensor tensor, int32_t* data_type, OpenVINOError* error);
-int32_t* openvino_tensor_get_shape(OpenVINOTensor tensor, int32_t* shape_size, OpenVINOError* error);
-void openvino_tensor_free_shape(int32_t* shape);
+int64_t* openvino_tensor_get_shape(OpenVINOTensor tensor, int32_t* shape_size, OpenVINOError* error);
+void openvino_tensor_free_shape(int64_t* shape);
+// Returns the byte strides of each dimension; free with openvino_tensor_free_shape.
+int64_t* openvino_tensor_get_strides(OpenVINOTensor tensor, int32_t* rank, OpenVINOError* error);
+// Returns 1 if the tensor's elements are contiguous in memory, 0 if not and -1 on error.
+int32_t openvino_tensor_is_continuous(OpenVINOTensor tensor, OpenVINOError* error);
+// Creates a tensor viewing the region [begin, end) of tensor without copying.
+OpenVINOTensor openvino_tensor_new_roi(OpenVINOTensor tensor, const int64_t* begin, const int64_t* end, int32_t rank, OpenVINOError* error);
+// Copies src into dst, which is reshaped to match unless it is a region of interest.
+int32_t openvino_tensor_copy_to(OpenVINOTensor src, OpenVINOTensor dst, OpenVINOError* error);
 void openvino_tensor_destroy(OpenVINOTensor tensor);
 
 // Tensor creation
 OpenVINOTensor openvino_tensor_new(
     int32_t data_type,
-    int32_t* shape,
+    const int64_t* shape,
     int32_t shape_size,
     OpenVINOError* error
 );
 
 OpenVINOTensor openvino_tensor_new_with_data(
     int32_t data_type,
-    int32_t* shape,
+    const int64_t* shape,
     int32_t shape_size,
     const void* data,
     OpenVINOError* error
 );
 
+// Wraps caller-owned memory without copying. data must hold the full tensor
+// and stay valid, unmoved, until the tensor and every copy of it (such as one
+// set on an infer request) are released.
+OpenVINOTensor openvino_tensor_new_from_host_ptr(
+    int32_t data_type,
+    const int64_t* shape,
+    int32_t shape_size,
+    void* data,
+    OpenVINOError* error
+);
+
 // Tensor metadata operations
 int64_t openvino_tensor_get_size(OpenVINOTensor tensor, OpenVINOError* error);
 int64_t openvino_tensor_get_byte_size(OpenVINOTensor tensor, OpenVINOError* error);
 int32_t openvino_tensor_get_element_type(OpenVINOTensor tensor, OpenVINOError* error);
-int32_t openvino_tensor_set_shape(OpenVINOTensor tensor, int32_t* shape, int32_t shape_size, OpenVINOError* error);
+int32_t openvino_tensor_set_shape(OpenVINOTensor tensor, const int64_t* shape, int32_t shape_size, OpenVINOError* error);
+
+// String tensors. set_strings assigns string i from data[offsets[i]:offsets[i+1]],
+// so offsets holds count+1 entries. get_strings stores pointers into the
+// tensor's strings, valid until the tensor is changed or destroyed.
+int32_t openvino_tensor_set_strings(OpenVINOTensor tensor, const char* data, const int64_t* offsets, int64_t count, OpenVINOError* error);
+int32_t openvino_tensor_get_strings(OpenVINOTensor tensor, const char** data, int64_t* lengths, int64_t count, OpenVINOError* error);
 
 // Model I/O information
 typedef struct {
     char* name;
-    int32_t* shape;
-    int32_t shape_size;
+    int64_t* shape_min;   // Per-dimension lower bound
+    int64_t* shape_max;   // Per-dimension upper bound, -1 if unbounded
+    int32_t shape_size;   // Rank, or -1 if the rank is dynamic
     int32_t data_type;
+    char* layout;         // Layout such as "[N,C,H,W]", empty if unset
 } OpenVINOPortInfo;
 
 OpenVINOPortInfo* openvino_model_get_inputs(OpenVINOModel model, int32_t* count, OpenVINOError* error);
 OpenVINOPortInfo* openvino_model_get_outputs(OpenVINOModel model, int32_t* count, OpenVINOError* error);
+OpenVINOPortInfo* openvino_compiled_model_get_inputs(OpenVINOCompiledModel compiled_model, int32_t* count, OpenVINOError* error);
+OpenVINOPortInfo* openvino_compiled_model_get_outputs(OpenVINOCompiledModel compiled_model, int32_t* count, OpenVINOError* error);
+OpenVINOPortInfo* openvino_infer_request_get_inputs(OpenVINOInferRequest request, int32_t* count, OpenVINOError* error);
+OpenVINOPortInfo* openvino_infer_request_get_outputs(OpenVINOInferRequest request, int32_t* count, OpenVINOError* error);
 void openvino_model_free_port_info(OpenVINOPortInfo* ports, int32_t count);
 
+// Model reshaping. Each port's shape is given by rank and the next `rank`
+// entries of dims_min/dims_max; a dimension is static when min == max and
+// dims_max of -1 means unbounded.
+int32_t openvino_model_reshape(
+    OpenVINOModel model,
+    const char** names,      // Port tensor names, or NULL to address inputs by index
+    const int32_t* indices,  // Input indices, used when names is NULL
+    const int32_t* ranks,
+    const int64_t* dims_min,
+    const int64_t* dims_max,
+    int32_t port_count,
+    OpenVINOError* error
+);
+
+// Port layouts and batch size. The layout is set on the input or output
+// with the given tensor name. The batch is read from and applied to the
+// dimension named N in the input layouts; batch_max of -1 means unbounded.
+int32_t openvino_model_set_layout(OpenVINOModel model, const char* name, const char* layout, OpenVINOError* error);
+int32_t openvino_model_get_batch(OpenVINOModel model, int64_t* batch_min, int64_t* batch_max, OpenVINOError* error);
+int32_t openvino_model_set_batch(OpenVINOModel model, int64_t batch_min, int64_t batch_max, OpenVINOError* error);
+
+// Pre/post-processing. The processor works on a copy of the model, so the
+// source model is left untouched and build returns a new model. Ports are
+// addressed by tensor name, or by index when name is NULL; a NULL name and a
+// negative index select the model's only input or output.
+OpenVINOPrePostProcessor openvino_preprocessor_new(OpenVINOModel model, OpenVINOError* error);
+void openvino_preprocessor_destroy(OpenVINOPrePostProcessor ppp);
+OpenVINOModel openvino_preprocessor_build(OpenVINOPrePostProcessor ppp, OpenVINOError* error);
+
+int32_t openvino_preprocessor_input_tensor_set_element_type(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t data_type, OpenVINOError* error);
+int32_t openvino_preprocessor_input_tensor_set_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error);
+int32_t openvino_preprocessor_input_tensor_set_color_format(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t color_format, OpenVINOError* error);
+// height and width of -1 mark the spatial dimensions as dynamic.
+int32_t openvino_preprocessor_input_tensor_set_spatial_shape(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int64_t height, int64_t width, OpenVINOError* error);
+
+int32_t openvino_preprocessor_input_convert_element_type(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t data_type, OpenVINOError* error);
+int32_t openvino_preprocessor_input_convert_color(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t color_format, OpenVINOError* error);
+// height and width of -1 resize to the model's spatial dimensions.
+int32_t openvino_preprocessor_input_resize(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t algorithm, int64_t height, int64_t width, OpenVINOError* error);
+// A single value applies to every channel; otherwise one value per channel.
+int32_t openvino_preprocessor_input_mean(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const float* values, int32_t count, OpenVINOError* error);
+int32_t openvino_preprocessor_input_scale(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const float* values, int32_t count, OpenVINOError* error);
+// An empty layout converts to the model layout.
+int32_t openvino_preprocessor_input_convert_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error);
+int32_t openvino_preprocessor_input_reverse_channels(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, OpenVINOError* error);
+int32_t openvino_preprocessor_input_model_set_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error);
+
+int32_t openvino_preprocessor_output_tensor_set_element_type(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t data_type, OpenVINOError* error);
+int32_t openvino_preprocessor_output_tensor_set_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error);
+int32_t openvino_preprocessor_output_convert_element_type(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, int32_t data_type, OpenVINOError* error);
+int32_t openvino_preprocessor_output_convert_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error);
+int32_t openvino_preprocessor_output_model_set_layout(OpenVINOPrePostProcessor ppp, const char* name, int32_t index, const char* layout, OpenVINOError* error);
+
 // Error handling
 void openvino_error_free(OpenVINOError* error);
 
@@ -0,0 +1,203 @@
+package openvino
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"sync"
+)
+
+// AsyncInferQueue is a pool of infer requests created from one compiled
+// model. Each submitted job runs on the next idle request, and Submit blocks
+// while every request is busy. It is safe for concurrent use.
+type AsyncInferQueue struct {
+	requests []*InferRequest
+	idle     chan *InferRequest
+	done     chan struct{} // closed by Close to wake blocked Submit calls
+
+	mu       sync.Mutex
+	drained  *sync.Cond // signalled when pending drops to zero
+	pending  int
+	closed   bool
+	callback func(req *InferRequest, userdata interface{}, err error)
+}
+
+// NewAsyncInferQueue creates a queue of infer requests for the compiled
+// model. The queue holds OPTIMAL_NUMBER_OF_INFER_REQUESTS requests unless
+// QueueSize is given. The compiled model may be closed while the queue is in
+// use; the queue must be closed with Close.
+func (cm *CompiledModel) NewAsyncInferQueue(options ...QueueOption) (*AsyncInferQueue, error) {
+	const op = "CompiledModel.NewAsyncInferQueue"
+	if _, err := cm.handle(op); err != nil {
+		return nil, err
+	}
+	var cfg queueConfig
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	size := cfg.size
+	if size <= 0 {
+		n, err := cm.OptimalNumberOfInferRequests()
+		if err != nil {
+			return nil, err
+		}
+		size = max(n, 1)
+	}
+
+	q := &AsyncInferQueue{
+		idle: make(chan *InferRequest, size),
+		done: make(chan struct{}),
+	}
+	q.drained = sync.NewCond(&q.mu)
+	for i := 0; i < size; i++ {
+		req, err := cm.CreateInferRequest()
+		if err != nil {
+			for _, r := range q.requests {
+				r.Close()
+			}
+			return nil, err
+		}
+		q.requests = append(q.requests, req)
+		q.idle <- req
+	}
+	return q, nil
+}
+
+// Size returns the number of infer requests in the queue.
+func (q *AsyncInferQueue) Size() int {
+	return len(q.requests)
+}
+
+// Request returns the i-th infer request of the queue, for setup such as
+// SetOutputTensor that applies to every job run on it. It must not be used
+// to run inference directly.
+func (q *AsyncInferQueue) Request(i int) *InferRequest {
+	return q.requests[i]
+}
+
+// IsReady reports whether a request is idle, so that Submit would not block.
+func (q *AsyncInferQueue) IsReady() bool {
+	return len(q.idle) > 0
+}
+
+// SetCallback sets the function called when each job completes, with the
+// request the job ran on, the userdata given to Submit, and nil or the
+// inference error. Callbacks run on their own goroutines and may run
+// concurrently. The request is handed to the next job once the callback
+// returns, so outputs must be read or copied before then. A panic in the
+// callback is recovered and logged. Pass nil to clear the callback.
+func (q *AsyncInferQueue) SetCallback(callback func(req *InferRequest, userdata interface{}, err error)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.callback = callback
+}
+
+// Submit sets inputs, keyed by input tensor name, on the next idle request
+// and starts inference on it, blocking while every request is busy. It
+// returns once the job has started; completion is reported to the callback.
+// ctx only bounds the wait for an idle request: if it ends first, Submit
+// returns an error matching ErrInferenceCancelled. A job that has started
+// runs to completion even if ctx ends afterwards.
+// The queue stops referencing the input tensors once the callback returns.
+func (q *AsyncInferQueue) Submit(ctx context.Context, inputs map[string]*Tensor, userdata interface{}) error {
+	const op = "AsyncInferQueue.Submit"
+	if err := ctx.Err(); err != nil {
+		return cancelledError(op, err)
+	}
+	var req *InferRequest
+	select {
+	case req = <-q.idle:
+	case <-q.done:
+		return closedError(op, "AsyncInferQueue")
+	case <-ctx.Done():
+		return cancelledError(op, ctx.Err())
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		q.idle <- req
+		return closedError(op, "AsyncInferQueue")
+	}
+	q.pending++
+	q.mu.Unlock()
+
+	for name, tensor := range inputs {
+		if err := req.SetTensor(name, tensor); err != nil {
+			q.release(req)
+			return err
+		}
+	}
+	request, err := req.handle(op)
+	if err != nil {
+		q.release(req)
+		return err
+	}
+	if err := request.StartAsync(); err != nil {
+		q.release(req)
+		return wrapError(err, ErrInferenceFailed, op)
+	}
+	go func() {
+		defer q.release(req)
+		err := wrapError(request.Wait(), ErrInferenceFailed, op)
+		q.mu.Lock()
+		callback := q.callback
+		q.mu.Unlock()
+		if callback != nil {
+			runJobCallback(callback, req, userdata, err)
+		}
+		req.forget(inputs)
+	}()
+	return nil
+}
+
+// runJobCallback calls callback, recovering a panic so that the request is
+// still returned to the queue.
+func runJobCallback(callback func(*InferRequest, interface{}, error), req *InferRequest, userdata interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("openvino: panic in AsyncInferQueue callback: %v\n%s", r, debug.Stack())
+		}
+	}()
+	callback(req, userdata, err)
+}
+
+// release returns req to the idle requests once its job is done.
+func (q *AsyncInferQueue) release(req *InferRequest) {
+	q.idle <- req
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending--
+	if q.pending == 0 {
+		q.drained.Broadcast()
+	}
+}
+
+// WaitAll blocks until every submitted job has completed and its callback
+// has returned. It must not be called from a callback.
+func (q *AsyncInferQueue) WaitAll() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.pending > 0 {
+		q.drained.Wait()
+	}
+}
+
+// Close stops the queue: Submit calls that are blocked or made later fail
+// with ErrClosed, jobs already submitted run to completion, and the requests
+// are closed once their callbacks have returned. It must not be called from
+// a callback. It is safe to call Close more than once.
+func (q *AsyncInferQueue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+	for q.pending > 0 {
+		q.drained.Wait()
+	}
+	q.mu.Unlock()
+	for _, req := range q.requests {
+		req.Close()
+	}
+}
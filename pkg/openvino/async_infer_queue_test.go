@@ -0,0 +1,209 @@
+package openvino
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestQueue(t *testing.T, options ...QueueOption) (*Core, *CompiledModel, *AsyncInferQueue) {
+	t.Helper()
+	core := coreAvailable(t)
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		core.Close()
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	queue, err := compiled.NewAsyncInferQueue(options...)
+	if err != nil {
+		compiled.Close()
+		core.Close()
+		t.Fatalf("NewAsyncInferQueue failed: %v", err)
+	}
+	return core, compiled, queue
+}
+
+func TestAsyncInferQueue(t *testing.T) {
+	core, compiled, queue := newTestQueue(t, QueueSize(2))
+	defer core.Close()
+	defer compiled.Close()
+	defer queue.Close()
+
+	if queue.Size() != 2 {
+		t.Fatalf("Size() = %d, want 2", queue.Size())
+	}
+
+	const jobs = 16
+	var mu sync.Mutex
+	results := make(map[int]float32)
+	queue.SetCallback(func(req *InferRequest, userdata interface{}, err error) {
+		if err != nil {
+			t.Errorf("job %v failed: %v", userdata, err)
+			return
+		}
+		output, err := req.GetOutputTensor("output")
+		if err != nil {
+			t.Errorf("GetOutputTensor failed: %v", err)
+			return
+		}
+		defer output.Close()
+		data, err := output.GetDataAsFloat32()
+		if err != nil {
+			t.Errorf("GetDataAsFloat32 failed: %v", err)
+			return
+		}
+		mu.Lock()
+		results[userdata.(int)] = data[0]
+		mu.Unlock()
+	})
+
+	for i := 0; i < jobs; i++ {
+		input, err := NewTensorWithData(DataTypeFloat32, []int64{1, 4}, []float32{float32(i), 0, 0, 0})
+		if err != nil {
+			t.Fatalf("NewTensorWithData failed: %v", err)
+		}
+		defer input.Close()
+		err = queue.Submit(context.Background(), map[string]*Tensor{"input": input}, i)
+		if err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+	queue.WaitAll()
+
+	if len(results) != jobs {
+		t.Fatalf("got %d results, want %d", len(results), jobs)
+	}
+	for i := 0; i < jobs; i++ {
+		if want := float32(i + 1); results[i] != want {
+			t.Errorf("job %d: output[0] = %v, want %v", i, results[i], want)
+		}
+	}
+	if !queue.IsReady() {
+		t.Error("IsReady() = false after WaitAll")
+	}
+	for i := 0; i < queue.Size(); i++ {
+		if kept := queue.Request(i).kept; len(kept) != 0 {
+			t.Errorf("request %d still holds input tensors %v after WaitAll", i, kept)
+		}
+	}
+}
+
+func TestAsyncInferQueue_optimalSize(t *testing.T) {
+	core, compiled, queue := newTestQueue(t)
+	defer core.Close()
+	defer compiled.Close()
+	defer queue.Close()
+
+	n, err := compiled.OptimalNumberOfInferRequests()
+	if err != nil {
+		t.Fatalf("OptimalNumberOfInferRequests failed: %v", err)
+	}
+	if queue.Size() != max(n, 1) {
+		t.Errorf("Size() = %d, want %d", queue.Size(), max(n, 1))
+	}
+}
+
+func TestAsyncInferQueue_backpressure(t *testing.T) {
+	core, compiled, queue := newTestQueue(t, QueueSize(1))
+	defer core.Close()
+	defer compiled.Close()
+	defer queue.Close()
+
+	input, err := NewTensorWithData(DataTypeFloat32, []int64{1, 4}, []float32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer input.Close()
+	inputs := map[string]*Tensor{"input": input}
+
+	release := make(chan struct{})
+	queue.SetCallback(func(*InferRequest, interface{}, error) { <-release })
+	if err := queue.Submit(context.Background(), inputs, nil); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	// The only request is held by the blocked callback, so Submit waits
+	// until its context ends.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = queue.Submit(ctx, inputs, nil)
+	if !errors.Is(err, ErrInferenceCancelled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Submit on a full queue with a cancelled context: got %v, want ErrInferenceCancelled", err)
+	}
+	close(release)
+	queue.WaitAll()
+}
+
+func TestAsyncInferQueue_Close(t *testing.T) {
+	core, compiled, queue := newTestQueue(t, QueueSize(2))
+	defer core.Close()
+	defer compiled.Close()
+
+	input, err := NewTensorWithData(DataTypeFloat32, []int64{1, 4}, []float32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer input.Close()
+
+	var completed int
+	var mu sync.Mutex
+	queue.SetCallback(func(_ *InferRequest, _ interface{}, err error) {
+		if err != nil {
+			t.Errorf("job failed: %v", err)
+		}
+		mu.Lock()
+		completed++
+		mu.Unlock()
+	})
+	for i := 0; i < 4; i++ {
+		if err := queue.Submit(context.Background(), map[string]*Tensor{"input": input}, i); err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+
+	// Close lets submitted jobs finish before closing the requests.
+	queue.Close()
+	if completed != 4 {
+		t.Errorf("%d jobs completed before Close returned, want 4", completed)
+	}
+	err = queue.Submit(context.Background(), map[string]*Tensor{"input": input}, nil)
+	if !errors.Is(err, ErrClosed) {
+		t.Errorf("Submit after Close: got %v, want ErrClosed", err)
+	}
+	queue.Close()
+}
+
+func TestAsyncInferQueue_submitContextEnds(t *testing.T) {
+	core, compiled, queue := newTestQueue(t, QueueSize(1))
+	defer core.Close()
+	defer compiled.Close()
+	defer queue.Close()
+
+	input, err := NewTensorWithData(DataTypeFloat32, []int64{1, 4}, []float32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer input.Close()
+
+	results := make(chan error, 1)
+	queue.SetCallback(func(_ *InferRequest, _ interface{}, err error) { results <- err })
+
+	// The context of Submit ends as soon as it returns; the job still runs.
+	submit := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		return queue.Submit(ctx, map[string]*Tensor{"input": input}, nil)
+	}
+	if err := submit(); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	queue.WaitAll()
+	if err := <-results; err != nil {
+		t.Errorf("job failed after the Submit context ended: %v", err)
+	}
+}
@@ -23,7 +23,7 @@ func TestInferRequest_SetInputTensors(t *testing.T) {
 	}
 
 	// Check if model has batch dimension
-	shape := inputs[0].Shape
+	shape := shapeToInt64(inputs[0].Shape)
 	if len(shape) == 0 {
 		t.Skip("first input has no shape")
 	}
@@ -49,7 +49,7 @@ func TestInferRequest_SetInputTensors(t *testing.T) {
 
 	// Create batch of tensors
 	batchSize := 2
-	if shape[0] > 0 && shape[0] < int32(batchSize) {
+	if shape[0] > 0 && shape[0] < int64(batchSize) {
 		batchSize = int(shape[0])
 	}
 
@@ -59,7 +59,7 @@ func TestInferRequest_SetInputTensors(t *testing.T) {
 		tensorShape := []int64{1}
 		for j := 1; j < len(shape); j++ {
 			if shape[j] > 0 {
-				tensorShape = append(tensorShape, int64(shape[j]))
+				tensorShape = append(tensorShape, shape[j])
 			} else {
 				tensorShape = append(tensorShape, 224) // default
 			}
@@ -125,7 +125,7 @@ func TestInferRequest_SetInputTensorsByIndex(t *testing.T) {
 	}
 	defer req.Close()
 
-	shape := inputs[0].Shape
+	shape := shapeToInt64(inputs[0].Shape)
 	if len(shape) == 0 {
 		t.Skip("first input has no shape")
 	}
@@ -142,7 +142,7 @@ func TestInferRequest_SetInputTensorsByIndex(t *testing.T) {
 		tensorShape := []int64{1}
 		for j := 1; j < len(shape); j++ {
 			if shape[j] > 0 {
-				tensorShape = append(tensorShape, int64(shape[j]))
+				tensorShape = append(tensorShape, shape[j])
 			} else {
 				tensorShape = append(tensorShape, 224)
 			}
@@ -0,0 +1,614 @@
+package openvino
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxBatch = 16
+	defaultMaxDelay = time.Millisecond
+)
+
+// Batcher combines single-item inferences from concurrent callers into
+// batched inferences. Items are stacked along the batch dimension of each
+// input, found through the N of its layout or else taken as the first
+// dimension, and the outputs are split back to the callers. Models with a
+// static batch size always run full batches, with unused rows zeroed; models
+// with a dynamic batch dimension run batches of the items collected.
+// It is safe for concurrent use.
+type Batcher struct {
+	queue    *AsyncInferQueue
+	inputs   []batchPort
+	outputs  []batchPort
+	minRows  int64 // smallest batch the model accepts; unused rows are zeroed
+	maxBatch int
+	maxDelay time.Duration
+
+	mu      sync.Mutex
+	pending []*batchItem
+	closed  bool
+	wake    chan struct{} // signalled when items arrive or the batcher closes
+	stopped chan struct{} // closed when the dispatcher exits
+}
+
+// batchPort is a model input or output with its batch axis and, for inputs,
+// its padding.
+type batchPort struct {
+	PortInfo
+	index int32
+	axis  int
+	pad   *batchPad
+}
+
+type batchItem struct {
+	inputs  map[string]*Tensor
+	key     string // items with equal keys can share a batch
+	arrived time.Time
+	staged  chan struct{} // closed once the inputs have been copied
+	result  chan batchResult
+	// abandoned is set, under Batcher.mu, when the caller stopped waiting
+	// after the item was batched.
+	abandoned bool
+}
+
+type batchResult struct {
+	outputs map[string]*Tensor
+	err     error
+}
+
+// batchJob is the userdata of a batch submitted to the queue.
+type batchJob struct {
+	items  []*batchItem
+	inputs map[string]*Tensor
+}
+
+// NewBatcher creates a batcher over the compiled model. Every input and
+// output needs a static rank and a batch dimension, and the batch dimensions
+// of the inputs must agree. The compiled model may be closed while the
+// batcher is in use; the batcher must be closed with Close.
+func (cm *CompiledModel) NewBatcher(options ...BatcherOption) (*Batcher, error) {
+	const op = "CompiledModel.NewBatcher"
+	if _, err := cm.handle(op); err != nil {
+		return nil, err
+	}
+	cfg := batcherConfig{maxDelay: defaultMaxDelay}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	inputs, err := cm.GetInputs()
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := cm.GetOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Batcher{
+		maxBatch: cfg.maxBatch,
+		maxDelay: cfg.maxDelay,
+		wake:     make(chan struct{}, 1),
+		stopped:  make(chan struct{}),
+	}
+	if b.inputs, err = batchPorts(inputs, op); err != nil {
+		return nil, err
+	}
+	if b.outputs, err = batchPorts(outputs, op); err != nil {
+		return nil, err
+	}
+
+	if len(b.inputs) == 0 {
+		return nil, &Error{Message: "model has no inputs to batch", Op: op, kind: ErrParameterMismatch}
+	}
+	batch := b.inputs[0].Shape[b.inputs[0].axis]
+	for _, p := range b.inputs[1:] {
+		if p.Shape[p.axis] != batch {
+			return nil, &Error{Message: fmt.Sprintf("batch dimension %s does not match %s of input %q", p.Shape[p.axis], batch, b.inputs[0].Name), Op: op, Port: p.Name, kind: ErrParameterMismatch}
+		}
+	}
+	b.minRows = max(batch.Min, 1)
+	if b.maxBatch <= 0 {
+		b.maxBatch = defaultMaxBatch
+		if batch.IsStatic() {
+			b.maxBatch = int(batch.Min)
+		} else if batch.Max >= 0 {
+			b.maxBatch = min(b.maxBatch, int(batch.Max))
+		}
+	}
+	if batch.Max >= 0 && int64(b.maxBatch) > batch.Max {
+		return nil, &Error{Message: fmt.Sprintf("MaxBatch %d exceeds batch dimension %s", b.maxBatch, batch), Op: op, kind: ErrParameterMismatch}
+	}
+
+	for name, pad := range cfg.pads {
+		p := b.input(name)
+		if p == nil {
+			return nil, &Error{Code: ErrorCodeNotFound, Message: "model has no such input", Op: op, Port: name}
+		}
+		if pad.axis < 0 || pad.axis >= len(p.Shape) || pad.axis == p.axis {
+			return nil, &Error{Message: fmt.Sprintf("cannot pad axis %d", pad.axis), Op: op, Port: name, kind: ErrParameterMismatch}
+		}
+		if _, ok := scalarBytes(p.DataType, pad.value); !ok {
+			return nil, &Error{Message: fmt.Sprintf("cannot pad %s tensors with %v", p.DataType, pad.value), Op: op, Port: name, kind: ErrUnsupportedType}
+		}
+		pad := pad
+		p.pad = &pad
+	}
+
+	if b.queue, err = cm.NewAsyncInferQueue(QueueSize(cfg.requests)); err != nil {
+		return nil, err
+	}
+	b.queue.SetCallback(b.complete)
+	go b.run()
+	return b, nil
+}
+
+// batchPorts finds the batch axis of each port.
+func batchPorts(ports []PortInfo, op string) ([]batchPort, error) {
+	result := make([]batchPort, len(ports))
+	for i, p := range ports {
+		if p.Shape.IsRankDynamic() || len(p.Shape) == 0 {
+			return nil, &Error{Message: "port has no batch dimension", Op: op, Port: p.Name, kind: ErrParameterMismatch}
+		}
+		if p.DataType == DataTypeString || p.DataType.IsPacked() {
+			return nil, &Error{Message: fmt.Sprintf("cannot batch %s tensors", p.DataType), Op: op, Port: p.Name, kind: ErrUnsupportedType}
+		}
+		axis, ok := p.Layout.BatchIdx()
+		if !ok {
+			axis = 0
+		} else if axis < 0 {
+			axis += len(p.Shape)
+		}
+		result[i] = batchPort{PortInfo: p, index: int32(i), axis: axis}
+	}
+	return result, nil
+}
+
+func (b *Batcher) input(name string) *batchPort {
+	for i := range b.inputs {
+		if b.inputs[i].Name == name {
+			return &b.inputs[i]
+		}
+	}
+	return nil
+}
+
+// MaxBatch returns the largest number of items run in one inference.
+func (b *Batcher) MaxBatch() int {
+	return b.maxBatch
+}
+
+// Infer runs inference on one item and returns its outputs, keyed by output
+// name. inputs holds a tensor for every model input, keyed by name, with a
+// batch dimension of 1; the tensors must stay valid until Infer returns. The
+// caller owns the returned tensors and must close them.
+//
+// Infer blocks until the batch holding the item has run. If ctx ends first,
+// Infer returns an error matching ErrInferenceCancelled; the batch itself is
+// not cancelled, as it is shared with other callers.
+func (b *Batcher) Infer(ctx context.Context, inputs map[string]*Tensor) (map[string]*Tensor, error) {
+	const op = "Batcher.Infer"
+	if err := ctx.Err(); err != nil {
+		return nil, cancelledError(op, err)
+	}
+	item, err := b.newItem(inputs, op)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil, closedError(op, "Batcher")
+	}
+	b.pending = append(b.pending, item)
+	b.mu.Unlock()
+	b.signal()
+
+	select {
+	case r := <-item.result:
+		return r.outputs, r.err
+	case <-ctx.Done():
+	}
+
+	b.mu.Lock()
+	for i, pending := range b.pending {
+		if pending == item {
+			b.pending = append(b.pending[:i], b.pending[i+1:]...)
+			b.mu.Unlock()
+			return nil, cancelledError(op, ctx.Err())
+		}
+	}
+	b.mu.Unlock()
+	// The item is in a batch: wait until its inputs are no longer read, then
+	// leave the outputs to be released when they arrive.
+	<-item.staged
+	b.mu.Lock()
+	item.abandoned = true
+	b.mu.Unlock()
+	select {
+	case r := <-item.result:
+		return r.outputs, r.err
+	default:
+		return nil, cancelledError(op, ctx.Err())
+	}
+}
+
+// newItem checks inputs against the model inputs.
+func (b *Batcher) newItem(inputs map[string]*Tensor, op string) (*batchItem, error) {
+	for name := range inputs {
+		if b.input(name) == nil {
+			return nil, &Error{Code: ErrorCodeNotFound, Message: "model has no such input", Op: op, Port: name}
+		}
+	}
+	var key []byte
+	for _, p := range b.inputs {
+		tensor, ok := inputs[p.Name]
+		if !ok || tensor == nil {
+			return nil, &Error{Message: "missing input tensor", Op: op, Port: p.Name, kind: ErrParameterMismatch}
+		}
+		dataType, err := tensor.GetElementType()
+		if err != nil {
+			return nil, err
+		}
+		if dataType != p.DataType {
+			return nil, &Error{Message: fmt.Sprintf("element type %s, want %s", dataType, p.DataType), Op: op, Port: p.Name, kind: ErrParameterMismatch}
+		}
+		shape, err := tensor.GetShape()
+		if err != nil {
+			return nil, err
+		}
+		if len(shape) != len(p.Shape) || shape[p.axis] != 1 {
+			return nil, &Error{Message: fmt.Sprintf("shape %v is not a single item of %s", shape, p.Shape), Op: op, Port: p.Name, kind: ErrParameterMismatch}
+		}
+		for i, n := range shape {
+			d := p.Shape[i]
+			if i == p.axis {
+				continue
+			}
+			if p.pad != nil && i == p.pad.axis {
+				if d.Max >= 0 && n > d.Max {
+					return nil, &Error{Message: fmt.Sprintf("length %d exceeds dimension %s", n, d), Op: op, Port: p.Name, kind: ErrParameterMismatch}
+				}
+				// Padded lengths do not split batches.
+				n = -1
+			} else if !d.Compatible(n) {
+				return nil, &Error{Message: fmt.Sprintf("shape %v is not compatible with %s", shape, p.Shape), Op: op, Port: p.Name, kind: ErrParameterMismatch}
+			}
+			key = fmt.Appendf(key, "%d,", n)
+		}
+		key = append(key, ';')
+	}
+	return &batchItem{
+		inputs:  inputs,
+		key:     string(key),
+		arrived: time.Now(),
+		staged:  make(chan struct{}),
+		result:  make(chan batchResult, 1),
+	}, nil
+}
+
+func (b *Batcher) signal() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run dispatches batches until the batcher is closed and drained.
+func (b *Batcher) run() {
+	defer close(b.stopped)
+	for {
+		items := b.next()
+		if items == nil {
+			return
+		}
+		b.dispatch(items)
+	}
+}
+
+// next waits for a batch: up to MaxBatch items with the same shapes as the
+// oldest pending item, once that many are pending or the oldest has waited
+// MaxDelay. Pending items are flushed without delay once the batcher is
+// closed. next returns nil when the batcher is closed and drained.
+func (b *Batcher) next() []*batchItem {
+	timer := time.NewTimer(b.maxDelay)
+	defer timer.Stop()
+	for {
+		b.mu.Lock()
+		if len(b.pending) == 0 && b.closed {
+			b.mu.Unlock()
+			return nil
+		}
+		var wait <-chan time.Time
+		if len(b.pending) > 0 {
+			first := b.pending[0]
+			var items, rest []*batchItem
+			for _, item := range b.pending {
+				if len(items) < b.maxBatch && item.key == first.key {
+					items = append(items, item)
+				} else {
+					rest = append(rest, item)
+				}
+			}
+			delay := b.maxDelay - time.Since(first.arrived)
+			if len(items) == b.maxBatch || delay <= 0 || b.closed {
+				b.pending = rest
+				b.mu.Unlock()
+				return items
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(delay)
+			wait = timer.C
+		}
+		b.mu.Unlock()
+
+		select {
+		case <-b.wake:
+		case <-wait:
+		}
+	}
+}
+
+// dispatch stacks the inputs of items and submits them to the queue. Errors
+// are delivered to every item.
+func (b *Batcher) dispatch(items []*batchItem) {
+	const op = "Batcher.Infer"
+	inputs, err := b.stack(items, op)
+	for _, item := range items {
+		close(item.staged)
+	}
+	if err == nil {
+		job := &batchJob{items: items, inputs: inputs}
+		if err = b.queue.Submit(context.Background(), inputs, job); err == nil {
+			return
+		}
+	}
+	closeTensors(inputs)
+	for _, item := range items {
+		b.deliver(item, nil, err)
+	}
+}
+
+// stack builds the batched input tensors for items.
+func (b *Batcher) stack(items []*batchItem, op string) (map[string]*Tensor, error) {
+	rows := max(int64(len(items)), b.minRows)
+	batched := make(map[string]*Tensor, len(b.inputs))
+	for _, p := range b.inputs {
+		shape, err := items[0].inputs[p.Name].GetShape()
+		if err != nil {
+			closeTensors(batched)
+			return nil, err
+		}
+		shape[p.axis] = rows
+		if p.pad != nil {
+			if d := p.Shape[p.pad.axis]; d.IsStatic() {
+				shape[p.pad.axis] = d.Min
+			} else {
+				for _, item := range items[1:] {
+					s, err := item.inputs[p.Name].GetShape()
+					if err != nil {
+						closeTensors(batched)
+						return nil, err
+					}
+					shape[p.pad.axis] = max(shape[p.pad.axis], s[p.pad.axis])
+				}
+			}
+		}
+		tensor, err := NewTensor(p.DataType, shape)
+		if err != nil {
+			closeTensors(batched)
+			return nil, err
+		}
+		batched[p.Name] = tensor
+		if p.pad != nil || rows > int64(len(items)) {
+			var value float64
+			if p.pad != nil {
+				value = p.pad.value
+			}
+			if err := fillTensor(tensor, value, op); err != nil {
+				closeTensors(batched)
+				return nil, wrapPortError(err, nil, op, p.Name)
+			}
+		}
+		for i, item := range items {
+			if err := copyRow(item.inputs[p.Name], tensor, p.axis, int64(i)); err != nil {
+				closeTensors(batched)
+				return nil, wrapPortError(err, nil, op, p.Name)
+			}
+		}
+	}
+	return batched, nil
+}
+
+// copyRow copies src, which has one row along axis, into row i of dst.
+// Dimensions of src shorter than dst are copied to the start of dst.
+func copyRow(src, dst *Tensor, axis int, i int64) error {
+	shape, err := src.GetShape()
+	if err != nil {
+		return err
+	}
+	begin := make([]int64, len(shape))
+	end := append([]int64(nil), shape...)
+	begin[axis], end[axis] = i, i+1
+	roi, err := dst.ROI(begin, end)
+	if err != nil {
+		return err
+	}
+	defer roi.Close()
+	return src.CopyTo(roi)
+}
+
+// complete is the queue callback: it splits the outputs of a batch between
+// its items.
+func (b *Batcher) complete(req *InferRequest, userdata interface{}, err error) {
+	job := userdata.(*batchJob)
+	closeTensors(job.inputs)
+	if err != nil {
+		for _, item := range job.items {
+			b.deliver(item, nil, err)
+		}
+		return
+	}
+
+	results := make([]map[string]*Tensor, len(job.items))
+	for i := range results {
+		results[i] = make(map[string]*Tensor, len(b.outputs))
+	}
+	for _, p := range b.outputs {
+		if err = b.split(req, p, results); err != nil {
+			break
+		}
+	}
+	for i, item := range job.items {
+		if err != nil {
+			closeTensors(results[i])
+			b.deliver(item, nil, err)
+		} else {
+			b.deliver(item, results[i], nil)
+		}
+	}
+}
+
+// split copies row i of output p into results[i].
+func (b *Batcher) split(req *InferRequest, p batchPort, results []map[string]*Tensor) error {
+	const op = "Batcher.Infer"
+	output, err := req.GetOutputTensorByIndex(p.index)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+	shape, err := output.GetShape()
+	if err != nil {
+		return err
+	}
+	if len(shape) <= p.axis || shape[p.axis] < int64(len(results)) {
+		return &Error{Message: fmt.Sprintf("output shape %v has no batch of %d", shape, len(results)), Op: op, Port: p.Name, kind: ErrParameterMismatch}
+	}
+	begin := make([]int64, len(shape))
+	end := append([]int64(nil), shape...)
+	item := append([]int64(nil), shape...)
+	item[p.axis] = 1
+	for i := range results {
+		begin[p.axis], end[p.axis] = int64(i), int64(i)+1
+		roi, err := output.ROI(begin, end)
+		if err != nil {
+			return err
+		}
+		dst, err := NewTensor(p.DataType, item)
+		if err == nil {
+			err = roi.CopyTo(dst)
+		}
+		roi.Close()
+		if err != nil {
+			dst.Close()
+			return err
+		}
+		results[i][p.Name] = dst
+	}
+	return nil
+}
+
+// deliver hands the result to the caller waiting on item, or releases the
+// outputs if the caller has gone.
+func (b *Batcher) deliver(item *batchItem, outputs map[string]*Tensor, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if item.abandoned {
+		closeTensors(outputs)
+		return
+	}
+	item.result <- batchResult{outputs: outputs, err: err}
+}
+
+// Close stops the batcher. Pending items are run without waiting for
+// MaxDelay, and Close returns once every batch has completed. Infer calls
+// made afterwards fail with ErrClosed. It is safe to call Close more than
+// once.
+func (b *Batcher) Close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	b.signal()
+	<-b.stopped
+	b.queue.Close()
+}
+
+func closeTensors(tensors map[string]*Tensor) {
+	for _, t := range tensors {
+		t.Close()
+	}
+}
+
+// fillTensor sets every element of tensor to value, which scalarBytes must
+// accept for its element type. It writes through the tensor's memory.
+func fillTensor(tensor *Tensor, value float64, op string) error {
+	t, err := tensor.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(tensor)
+	data, err := rawView(t, op)
+	if err != nil {
+		return err
+	}
+	if value == 0 {
+		clear(data)
+		return nil
+	}
+	dataType, err := t.GetElementType()
+	if err != nil {
+		return wrapError(err, ErrInvalidTensor, op)
+	}
+	elem, _ := scalarBytes(dataType, value)
+	for i := 0; i < len(data); i += len(elem) {
+		copy(data[i:], elem)
+	}
+	return nil
+}
+
+// scalarBytes encodes value as one element of dataType in host byte order.
+// Only zero can be encoded for types without a direct conversion.
+func scalarBytes(dataType DataType, value float64) ([]byte, bool) {
+	order := binary.NativeEndian
+	var b []byte
+	switch dataType {
+	case DataTypeFloat32:
+		b = order.AppendUint32(b, math.Float32bits(float32(value)))
+	case DataTypeFloat64:
+		b = order.AppendUint64(b, math.Float64bits(value))
+	case DataTypeFloat16:
+		b = order.AppendUint16(b, uint16(NewFloat16(float32(value))))
+	case DataTypeBFloat16:
+		b = order.AppendUint16(b, uint16(NewBFloat16(float32(value))))
+	case DataTypeInt8, DataTypeUint8:
+		b = append(b, byte(int64(value)))
+	case DataTypeBoolean:
+		if value != 0 {
+			b = append(b, 1)
+		} else {
+			b = append(b, 0)
+		}
+	case DataTypeInt16, DataTypeUint16:
+		b = order.AppendUint16(b, uint16(int64(value)))
+	case DataTypeInt32, DataTypeUint32:
+		b = order.AppendUint32(b, uint32(int64(value)))
+	case DataTypeInt64:
+		b = order.AppendUint64(b, uint64(int64(value)))
+	case DataTypeUint64:
+		b = order.AppendUint64(b, uint64(value))
+	default:
+		return nil, value == 0
+	}
+	return b, true
+}
@@ -0,0 +1,238 @@
+package openvino
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestBatcher compiles the test IR model with its input reshaped to shape
+// and creates a batcher over it.
+func newTestBatcher(t *testing.T, shape PartialShape, options ...BatcherOption) *Batcher {
+	t.Helper()
+	core := coreAvailable(t)
+	t.Cleanup(core.Close)
+	model := readTestIRModel(t, core)
+	defer model.Close()
+	if err := model.Reshape(map[string]PartialShape{"input": shape}); err != nil {
+		t.Fatalf("Reshape failed: %v", err)
+	}
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	batcher, err := compiled.NewBatcher(options...)
+	if err != nil {
+		t.Fatalf("NewBatcher failed: %v", err)
+	}
+	t.Cleanup(batcher.Close)
+	return batcher
+}
+
+// inferItem runs one item through the batcher and returns its output.
+func inferItem(b *Batcher, data []float32) ([]float32, error) {
+	input, err := NewTensorWithData(DataTypeFloat32, []int64{1, int64(len(data))}, data)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+	outputs, err := b.Infer(context.Background(), map[string]*Tensor{"input": input})
+	if err != nil {
+		return nil, err
+	}
+	defer closeTensors(outputs)
+	return outputs["output"].GetDataAsFloat32()
+}
+
+func testBatcherConcurrent(t *testing.T, b *Batcher, items int) {
+	var wg sync.WaitGroup
+	for i := 0; i < items; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			x := float32(i)
+			got, err := inferItem(b, []float32{x, x, x, x})
+			if err != nil {
+				t.Errorf("item %d: Infer failed: %v", i, err)
+				return
+			}
+			want := []float32{x + 1, x + 2, x + 3, x + 4}
+			for j := range want {
+				if got[j] != want[j] {
+					t.Errorf("item %d: output = %v, want %v", i, got, want)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestBatcher_dynamicBatch(t *testing.T) {
+	b := newTestBatcher(t, PartialShape{DynamicDim(), Dim(4)}, MaxBatch(8), MaxDelay(5*time.Millisecond))
+	if b.MaxBatch() != 8 {
+		t.Errorf("MaxBatch() = %d, want 8", b.MaxBatch())
+	}
+	testBatcherConcurrent(t, b, 40)
+}
+
+func TestBatcher_staticBatch(t *testing.T) {
+	b := newTestBatcher(t, NewPartialShape(4, 4))
+	if b.MaxBatch() != 4 {
+		t.Errorf("MaxBatch() = %d, want the static batch size 4", b.MaxBatch())
+	}
+	// 10 items do not fill the last batch, whose unused rows are zeroed.
+	testBatcherConcurrent(t, b, 10)
+}
+
+func TestBatcher_padding(t *testing.T) {
+	b := newTestBatcher(t, PartialShape{DynamicDim(), Dim(4)}, PadAxis("input", 1, 10))
+	got, err := inferItem(b, []float32{1, 1})
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	want := []float32{2, 3, 13, 14}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("output = %v, want %v", got, want)
+		}
+	}
+
+	if _, err := inferItem(b, []float32{1, 2, 3, 4, 5}); !errors.Is(err, ErrParameterMismatch) {
+		t.Errorf("Infer with an item longer than the padded dimension: got %v, want ErrParameterMismatch", err)
+	}
+}
+
+func TestBatcher_invalidInputs(t *testing.T) {
+	b := newTestBatcher(t, PartialShape{DynamicDim(), Dim(4)})
+
+	input, err := NewTensor(DataTypeFloat32, []int64{2, 4})
+	if err != nil {
+		t.Fatalf("NewTensor failed: %v", err)
+	}
+	defer input.Close()
+	if _, err := b.Infer(context.Background(), map[string]*Tensor{"input": input}); !errors.Is(err, ErrParameterMismatch) {
+		t.Errorf("Infer with a batch of 2: got %v, want ErrParameterMismatch", err)
+	}
+	if _, err := b.Infer(context.Background(), nil); !errors.Is(err, ErrParameterMismatch) {
+		t.Errorf("Infer without inputs: got %v, want ErrParameterMismatch", err)
+	}
+	if _, err := b.Infer(context.Background(), map[string]*Tensor{"other": input}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Infer with an unknown input: got %v, want ErrNotFound", err)
+	}
+
+	b.Close()
+	if _, err := inferItem(b, []float32{1, 2, 3, 4}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Infer after Close: got %v, want ErrClosed", err)
+	}
+}
+
+func TestScalarBytes(t *testing.T) {
+	tests := []struct {
+		dataType DataType
+		value    float64
+		want     []byte
+	}{
+		{DataTypeFloat32, 1.5, binary.NativeEndian.AppendUint32(nil, math.Float32bits(1.5))},
+		{DataTypeFloat16, 1, binary.NativeEndian.AppendUint16(nil, 0x3c00)},
+		{DataTypeInt64, -1, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{DataTypeUint8, 7, []byte{7}},
+		{DataTypeBoolean, 2, []byte{1}},
+	}
+	for _, tt := range tests {
+		got, ok := scalarBytes(tt.dataType, tt.value)
+		if !ok || string(got) != string(tt.want) {
+			t.Errorf("scalarBytes(%s, %v) = %v, %t, want %v", tt.dataType, tt.value, got, ok, tt.want)
+		}
+	}
+	if _, ok := scalarBytes(DataTypeFloat8E4M3, 1); ok {
+		t.Error("scalarBytes(f8e4m3, 1) succeeded, want failure")
+	}
+	if _, ok := scalarBytes(DataTypeFloat8E4M3, 0); !ok {
+		t.Error("scalarBytes(f8e4m3, 0) failed; zero padding works for every type")
+	}
+}
+
+// stackItems stacks single-row float32 items the way a batch is built.
+func stackItems(t *testing.T, b *Batcher, rows ...[]float32) []float32 {
+	t.Helper()
+	var items []*batchItem
+	for _, data := range rows {
+		input, err := NewTensorWithData(DataTypeFloat32, []int64{1, int64(len(data))}, data)
+		if err != nil {
+			t.Fatalf("NewTensorWithData failed: %v", err)
+		}
+		defer input.Close()
+		item, err := b.newItem(map[string]*Tensor{"input": input}, "test")
+		if err != nil {
+			t.Fatalf("newItem failed: %v", err)
+		}
+		items = append(items, item)
+	}
+	batched, err := b.stack(items, "test")
+	if err != nil {
+		t.Fatalf("stack failed: %v", err)
+	}
+	defer closeTensors(batched)
+	data, err := batched["input"].GetDataAsFloat32()
+	if err != nil {
+		t.Fatalf("GetDataAsFloat32 failed: %v", err)
+	}
+	return data
+}
+
+func TestBatcher_stackZeroesUnusedRows(t *testing.T) {
+	b := newTestBatcher(t, NewPartialShape(4, 4))
+	got := stackItems(t, b, []float32{1, 2, 3, 4})
+	want := []float32{1, 2, 3, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	if len(got) != len(want) {
+		t.Fatalf("batch = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("batch = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestBatcher_stackPadding(t *testing.T) {
+	b := newTestBatcher(t, PartialShape{DynamicDim(), Dim(4)}, PadAxis("input", 1, 10))
+	got := stackItems(t, b, []float32{1, 2}, []float32{3, 4, 5})
+	want := []float32{1, 2, 10, 10, 3, 4, 5, 10}
+	if len(got) != len(want) {
+		t.Fatalf("batch = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("batch = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFillTensor(t *testing.T) {
+	tensor, err := NewTensor(DataTypeInt64, []int64{2, 3})
+	if err != nil {
+		t.Fatalf("NewTensor failed: %v", err)
+	}
+	defer tensor.Close()
+	for _, value := range []float64{-7, 0} {
+		if err := fillTensor(tensor, value, "test"); err != nil {
+			t.Fatalf("fillTensor failed: %v", err)
+		}
+		got, err := tensor.GetDataAsInt64()
+		if err != nil {
+			t.Fatalf("GetDataAsInt64 failed: %v", err)
+		}
+		for _, v := range got {
+			if v != int64(value) {
+				t.Fatalf("after fillTensor(%v): %v", value, got)
+			}
+		}
+	}
+}
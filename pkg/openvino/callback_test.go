@@ -1,6 +1,7 @@
 package openvino
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -36,7 +37,7 @@ func TestInferRequest_SetCallback(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -84,3 +85,135 @@ func TestInferRequest_SetCallback(t *testing.T) {
 		t.Fatalf("SetCallback(nil) failed: %v", err)
 	}
 }
+
+func TestInferRequest_SetCallback_multiShot(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	if err := SetInput(req, "input", []int64{1, 4}, []float32{1, 2, 3, 4}); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+
+	run := func(calls chan error) {
+		t.Helper()
+		if err := req.StartAsync(); err != nil {
+			t.Fatalf("StartAsync failed: %v", err)
+		}
+		if err := req.Wait(); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+		if calls == nil {
+			return
+		}
+		select {
+		case err := <-calls:
+			if err != nil {
+				t.Errorf("callback received error: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("callback was not called")
+		}
+	}
+
+	first := make(chan error, 10)
+	if err := req.SetCallback(func(err error) { first <- err }); err != nil {
+		t.Fatalf("SetCallback failed: %v", err)
+	}
+	// The callback fires on every completion, not just the first.
+	for i := 0; i < 3; i++ {
+		run(first)
+	}
+
+	// A panicking callback is recovered and the request stays usable.
+	if err := req.SetCallback(func(error) { panic("callback panic") }); err != nil {
+		t.Fatalf("SetCallback failed: %v", err)
+	}
+	run(nil)
+
+	// Replacing the callback between runs redirects later completions.
+	second := make(chan error, 10)
+	if err := req.SetCallback(func(err error) { second <- err }); err != nil {
+		t.Fatalf("SetCallback failed: %v", err)
+	}
+	run(second)
+	run(second)
+	if len(first) != 0 {
+		t.Errorf("replaced callback was called %d more times", len(first))
+	}
+
+	if err := req.SetCallback(nil); err != nil {
+		t.Fatalf("SetCallback(nil) failed: %v", err)
+	}
+	run(nil)
+	time.Sleep(50 * time.Millisecond)
+	if len(second) != 0 {
+		t.Errorf("cleared callback was called %d more times", len(second))
+	}
+}
+
+func TestInferRequest_SetCallback_synchronous(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	if err := SetInput(req, "input", []int64{1, 4}, []float32{1, 2, 3, 4}); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	calls := make(chan error, 10)
+	if err := req.SetCallback(func(err error) { calls <- err }); err != nil {
+		t.Fatalf("SetCallback failed: %v", err)
+	}
+
+	// Synchronous inference does not call the callback.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := req.InferWithContext(ctx); err != nil {
+		t.Fatalf("InferWithContext failed: %v", err)
+	}
+	if err := req.Infer(); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(calls) != 0 {
+		t.Errorf("callback called %d times by synchronous inference", len(calls))
+	}
+
+	// Asynchronous inference does.
+	if err := req.InferAsyncWithContext(ctx); err != nil {
+		t.Fatalf("InferAsyncWithContext failed: %v", err)
+	}
+	select {
+	case <-calls:
+	case <-time.After(5 * time.Second):
+		t.Error("callback was not called by InferAsyncWithContext")
+	}
+}
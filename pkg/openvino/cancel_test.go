@@ -1,6 +1,8 @@
 package openvino
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -67,7 +69,7 @@ func TestInferRequest_CancelAsync(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -88,3 +90,72 @@ func TestInferRequest_CancelAsync(t *testing.T) {
 	// Wait a bit
 	time.Sleep(10 * time.Millisecond)
 }
+
+func TestInferRequest_InferWithContext_cancelReusable(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	for _, infer := range []func(*InferRequest, context.Context) error{
+		(*InferRequest).InferWithContext,
+		(*InferRequest).InferAsyncWithContext,
+	} {
+		for i := 0; i < 20; i++ {
+			if err := SetInput(req, "input", []int64{1, 4}, []float32{1, 2, 3, 4}); err != nil {
+				t.Fatalf("SetInput failed: %v", err)
+			}
+			// Cancel at varying points: before, during or after the inference.
+			ctx, cancel := context.WithCancel(context.Background())
+			go func(d time.Duration) {
+				time.Sleep(d)
+				cancel()
+			}(time.Duration(i) * 50 * time.Microsecond)
+			err := infer(req, ctx)
+			cancel()
+			if err != nil && !(errors.Is(err, ErrInferenceCancelled) && errors.Is(err, context.Canceled)) {
+				t.Fatalf("inference with a cancelled context: got %v, want nil or ErrInferenceCancelled", err)
+			}
+
+			// The request is idle again and can be reused straight away.
+			if err := req.Infer(); err != nil {
+				t.Fatalf("Infer after cancellation failed: %v", err)
+			}
+			output, err := req.GetOutputTensor("output")
+			if err != nil {
+				t.Fatalf("GetOutputTensor failed: %v", err)
+			}
+			got, err := output.GetDataAsFloat32()
+			output.Close()
+			if err != nil {
+				t.Fatalf("GetDataAsFloat32 failed: %v", err)
+			}
+			if got[0] != 2 || got[3] != 8 {
+				t.Fatalf("output after cancellation = %v, want [2 4 6 8]", got)
+			}
+		}
+	}
+}
+
+func TestInferRequest_InferWithContext_deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	// The context has ended, so the request is never touched.
+	err := (&InferRequest{}).InferWithContext(ctx)
+	if !errors.Is(err, ErrInferenceCancelled) || !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrCancelled) {
+		t.Errorf("InferWithContext with an expired deadline: got %v, want ErrInferenceCancelled and context.DeadlineExceeded", err)
+	}
+}
@@ -1,39 +1,181 @@
 package openvino
 
-import "github.com/accretional/openvino-go/internal/cgo"
+import (
+	"io"
+	"runtime"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
 
 type CompiledModel struct {
 	compiled *cgo.CompiledModel
 }
 
+func newCompiledModel(compiled *cgo.CompiledModel) *CompiledModel {
+	return trackHandle(&CompiledModel{compiled: compiled}, "CompiledModel", (*CompiledModel).Close)
+}
+
 func (c *Core) CompileModel(model *Model, device string, options ...CompileOption) (*CompiledModel, error) {
+	const op = "Core.CompileModel"
+	core, err := c.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	m, err := model.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(c)
+	defer runtime.KeepAlive(model)
+
 	props := make(map[string]string)
 	for _, opt := range options {
 		opt(props)
 	}
 
 	var compiled *cgo.CompiledModel
-	var err error
-
 	if len(props) > 0 {
-		compiled, err = c.core.CompileModelWithProperties(model.model, device, props)
+		compiled, err = core.CompileModelWithProperties(m, device, props)
 	} else {
-		compiled, err = c.core.CompileModel(model.model, device)
+		compiled, err = core.CompileModel(m, device)
 	}
 
+	if err != nil {
+		return nil, wrapDeviceError(err, ErrModelCompileFailed, op, device)
+	}
+	return newCompiledModel(compiled), nil
+}
+
+// ImportModel loads a compiled model previously written by CompiledModel.Export.
+// The blob is streamed from r in chunks. Seekable readers such as *os.File are
+// read in place. For other readers, device plugins that seek back within the
+// blob make the wrapper hold the data from the first position they record.
+func (c *Core) ImportModel(r io.Reader, device string, options ...CompileOption) (*CompiledModel, error) {
+	const op = "Core.ImportModel"
+	core, err := c.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &CompiledModel{compiled: compiled}, nil
+	defer runtime.KeepAlive(c)
+
+	props := make(map[string]string)
+	for _, opt := range options {
+		opt(props)
+	}
+
+	compiled, err := core.ImportModel(r, device, props)
+	if err != nil {
+		return nil, wrapDeviceError(err, ErrModelLoadFailed, op, device)
+	}
+	return newCompiledModel(compiled), nil
 }
 
+// Close releases the compiled model. Infer requests created from it stay
+// valid. It is safe to call Close more than once.
 func (cm *CompiledModel) Close() {
 	if cm.compiled != nil {
+		runtime.SetFinalizer(cm, nil)
 		cm.compiled.Destroy()
+		cm.compiled = nil
+	}
+}
+
+func (cm *CompiledModel) handle(op string) (*cgo.CompiledModel, error) {
+	if cm.compiled == nil {
+		return nil, closedError(op, "CompiledModel")
+	}
+	return cm.compiled, nil
+}
+
+// Export writes the compiled model blob to w so it can be loaded later with
+// Core.ImportModel on the same device type. The blob is streamed in chunks;
+// when w is not seekable, data from the first position a device plugin
+// records to patch later is held by the wrapper until the export finishes.
+func (cm *CompiledModel) Export(w io.Writer) error {
+	const op = "CompiledModel.Export"
+	compiled, err := cm.handle(op)
+	if err != nil {
+		return err
 	}
+	defer runtime.KeepAlive(cm)
+	return wrapError(compiled.Export(w), nil, op)
+}
+
+// GetProperty reads a property of the compiled model, such as
+// OPTIMAL_NUMBER_OF_INFER_REQUESTS or LOADED_FROM_CACHE.
+func (cm *CompiledModel) GetProperty(name string) (PropertyValue, error) {
+	const op = "CompiledModel.GetProperty"
+	compiled, err := cm.handle(op)
+	if err != nil {
+		return PropertyValue{}, err
+	}
+	defer runtime.KeepAlive(cm)
+	prop, err := compiled.GetProperty(name)
+	if err != nil {
+		return PropertyValue{}, wrapError(err, nil, op)
+	}
+	return propertyValueFromCgo(prop), nil
+}
+
+// LoadedFromCache reports whether the model was imported from the CACHE_DIR
+// model cache instead of being compiled.
+func (cm *CompiledModel) LoadedFromCache() (bool, error) {
+	v, err := cm.GetProperty(PropertyLoadedFromCache)
+	if err != nil {
+		return false, err
+	}
+	return v.Bool()
+}
+
+// GetInputs describes the inputs of the compiled model, with the shapes and
+// element types the device expects after preprocessing and reshaping.
+func (cm *CompiledModel) GetInputs() ([]PortInfo, error) {
+	const op = "CompiledModel.GetInputs"
+	compiled, err := cm.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(cm)
+	ports, err := compiled.GetInputs()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return portInfoFromCgo(ports), nil
+}
+
+// GetOutputs describes the outputs of the compiled model.
+func (cm *CompiledModel) GetOutputs() ([]PortInfo, error) {
+	const op = "CompiledModel.GetOutputs"
+	compiled, err := cm.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(cm)
+	ports, err := compiled.GetOutputs()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return portInfoFromCgo(ports), nil
+}
+
+// OptimalNumberOfInferRequests returns how many infer requests the device
+// suggests running in parallel for full throughput.
+func (cm *CompiledModel) OptimalNumberOfInferRequests() (int, error) {
+	v, err := cm.GetProperty(PropertyOptimalNumberOfInferRequests)
+	if err != nil {
+		return 0, err
+	}
+	n, err := v.Int()
+	return int(n), err
 }
 
 // ReleaseMemory releases memory allocated for intermediate structures when possible.
 func (cm *CompiledModel) ReleaseMemory() error {
-	return cm.compiled.ReleaseMemory()
+	const op = "CompiledModel.ReleaseMemory"
+	compiled, err := cm.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(cm)
+	return wrapError(compiled.ReleaseMemory(), nil, op)
 }
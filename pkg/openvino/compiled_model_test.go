@@ -1,6 +1,13 @@
 package openvino
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestCore_CompileModel(t *testing.T) {
 	core := coreAvailable(t)
@@ -26,6 +33,29 @@ func TestCore_CompileModel(t *testing.T) {
 	compiled.Close()
 }
 
+func TestCore_CompileModel_unknownDevice(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	_, err := core.CompileModel(model, "NO_SUCH_DEVICE")
+	if err == nil {
+		t.Fatal("CompileModel on an unknown device should return error")
+	}
+	if !errors.Is(err, ErrModelCompileFailed) {
+		t.Errorf("error %v does not match ErrModelCompileFailed", err)
+	}
+	if !errors.Is(err, ErrDeviceNotFound) {
+		t.Errorf("error %v does not match ErrDeviceNotFound", err)
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.Op != "Core.CompileModel" || e.Device != "NO_SUCH_DEVICE" {
+		t.Errorf("error lacks operation context: %#v", err)
+	}
+}
+
 func TestCore_CompileModel_withOptions(t *testing.T) {
 	core := coreAvailable(t)
 	defer core.Close()
@@ -85,3 +115,172 @@ func TestCompiledModel_CreateInferRequest(t *testing.T) {
 	}
 	req.Close()
 }
+
+func TestCore_CompileModel_cacheDir(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	modelPath := getTestModelPath(t)
+	if modelPath == "" {
+		t.Skip("no test model path")
+	}
+	model, err := core.ReadModel(modelPath)
+	if err != nil {
+		t.Skipf("cannot load model: %v", err)
+	}
+	defer model.Close()
+
+	cacheDir := t.TempDir()
+
+	first, err := core.CompileModel(model, "CPU", CacheDir(cacheDir))
+	if err != nil {
+		t.Skipf("CompileModel with CacheDir failed: %v", err)
+	}
+	cached, err := first.LoadedFromCache()
+	first.Close()
+	if err != nil {
+		t.Fatalf("LoadedFromCache failed: %v", err)
+	}
+	if cached {
+		t.Error("first compile into an empty cache dir should not be loaded from cache")
+	}
+
+	second, err := core.CompileModel(model, "CPU", CacheDir(cacheDir))
+	if err != nil {
+		t.Fatalf("second CompileModel with CacheDir failed: %v", err)
+	}
+	defer second.Close()
+	cached, err = second.LoadedFromCache()
+	if err != nil {
+		t.Fatalf("LoadedFromCache failed: %v", err)
+	}
+	if !cached {
+		t.Error("second compile into the same cache dir should be loaded from cache")
+	}
+}
+
+func TestCore_SetProperty_cacheDir(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	modelPath := getTestModelPath(t)
+	if modelPath == "" {
+		t.Skip("no test model path")
+	}
+	model, err := core.ReadModel(modelPath)
+	if err != nil {
+		t.Skipf("cannot load model: %v", err)
+	}
+	defer model.Close()
+
+	cacheDir := t.TempDir()
+	if err := core.SetProperty("", CacheDir(cacheDir)); err != nil {
+		t.Fatalf("SetProperty(CacheDir) failed: %v", err)
+	}
+	dir, err := core.GetProperty("", PropertyCacheDir)
+	if err != nil {
+		t.Fatalf("GetProperty(CACHE_DIR) failed: %v", err)
+	}
+	if dir.String() != cacheDir {
+		t.Errorf("CACHE_DIR = %q, want %q", dir.String(), cacheDir)
+	}
+
+	for i := 0; i < 2; i++ {
+		compiled, err := core.CompileModel(model, "CPU")
+		if err != nil {
+			t.Skipf("CompileModel failed: %v", err)
+		}
+		cached, err := compiled.LoadedFromCache()
+		compiled.Close()
+		if err != nil {
+			t.Fatalf("LoadedFromCache failed: %v", err)
+		}
+		if want := i > 0; cached != want {
+			t.Errorf("compile %d: LoadedFromCache = %v, want %v", i, cached, want)
+		}
+	}
+}
+
+func TestCompiledModel_ExportImport(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	modelPath := getTestModelPath(t)
+	if modelPath == "" {
+		t.Skip("no test model path")
+	}
+	model, err := core.ReadModel(modelPath)
+	if err != nil {
+		t.Skipf("cannot load model: %v", err)
+	}
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	var blob bytes.Buffer
+	if err := compiled.Export(&blob); err != nil {
+		t.Fatalf("Export to buffer failed: %v", err)
+	}
+	if blob.Len() == 0 {
+		t.Fatal("Export wrote no data")
+	}
+
+	path := filepath.Join(t.TempDir(), "model.blob")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create blob file: %v", err)
+	}
+	if err := compiled.Export(f); err != nil {
+		f.Close()
+		t.Fatalf("Export to file failed: %v", err)
+	}
+	f.Close()
+
+	fileBlob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read blob file: %v", err)
+	}
+	if !bytes.Equal(fileBlob, blob.Bytes()) {
+		t.Errorf("file export (%d bytes) differs from buffer export (%d bytes)", len(fileBlob), blob.Len())
+	}
+
+	// MultiReader hides Seek, exercising the buffered import path
+	imported, err := core.ImportModel(io.MultiReader(bytes.NewReader(blob.Bytes())), "CPU")
+	if err != nil {
+		t.Fatalf("ImportModel from reader failed: %v", err)
+	}
+	req, err := imported.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest on imported model failed: %v", err)
+	}
+	req.Close()
+	imported.Close()
+
+	f, err = os.Open(path)
+	if err != nil {
+		t.Fatalf("open blob file: %v", err)
+	}
+	defer f.Close()
+	imported, err = core.ImportModel(f, "CPU")
+	if err != nil {
+		t.Fatalf("ImportModel from file failed: %v", err)
+	}
+	imported.Close()
+}
+
+func TestCore_ImportModel_invalid(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	_, err := core.ImportModel(bytes.NewReader([]byte("not a compiled model")), "CPU")
+	if err == nil {
+		t.Fatal("ImportModel with garbage input should return error")
+	}
+	if !errors.Is(err, ErrModelLoadFailed) {
+		t.Errorf("ImportModel error %v does not match ErrModelLoadFailed", err)
+	}
+}
@@ -1,6 +1,10 @@
 package openvino
 
-import "github.com/accretional/openvino-go/internal/cgo"
+import (
+	"runtime"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
 
 type Core struct {
 	core *cgo.Core
@@ -9,17 +13,38 @@ type Core struct {
 func NewCore() (*Core, error) {
 	core, err := cgo.CreateCore()
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err, nil, "NewCore")
 	}
-	return &Core{core: core}, nil
+	return trackHandle(&Core{core: core}, "Core", (*Core).Close), nil
 }
 
+// Close releases the Core. Models and compiled models created from it stay
+// valid. It is safe to call Close more than once.
 func (c *Core) Close() {
 	if c.core != nil {
+		runtime.SetFinalizer(c, nil)
 		c.core.Destroy()
+		c.core = nil
+	}
+}
+
+func (c *Core) handle(op string) (*cgo.Core, error) {
+	if c.core == nil {
+		return nil, closedError(op, "Core")
 	}
+	return c.core, nil
 }
 
 func (c *Core) GetAvailableDevices() ([]string, error) {
-	return c.core.GetAvailableDevices()
+	const op = "Core.GetAvailableDevices"
+	core, err := c.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(c)
+	devices, err := core.GetAvailableDevices()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return devices, nil
 }
@@ -34,8 +34,8 @@
 //	}
 //	defer request.Close()
 //
-//	// Set input tensor and run inference
-//	err = request.SetInputTensor("input", data, shape, openvino.DataTypeFloat32)
+//	// Set input tensor and run inference; the element type follows from data
+//	err = openvino.SetInput(request, "input", shape, data)
 //	if err != nil {
 //		log.Fatal(err)
 //	}
@@ -51,7 +51,7 @@
 //	}
 //	defer output.Close()
 //
-//	result, err := output.GetDataAsFloat32()
+//	result, err := openvino.TensorData[float32](output)
 //	if err != nil {
 //		log.Fatal(err)
 //	}
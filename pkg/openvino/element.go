@@ -0,0 +1,39 @@
+package openvino
+
+// Element is the set of Go types that map directly onto a tensor element
+// type, so tensor memory can be viewed as a []T. Each type stands for exactly
+// one DataType, so generic functions such as TensorData and SetInput derive
+// the data type from T rather than taking it as an argument.
+type Element interface {
+	float32 | float64 | int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64 | Float16 | BFloat16
+}
+
+// elementDataType returns the DataType stored as T.
+func elementDataType[T Element]() DataType {
+	var zero T
+	switch any(zero).(type) {
+	case float32:
+		return DataTypeFloat32
+	case float64:
+		return DataTypeFloat64
+	case int8:
+		return DataTypeInt8
+	case int16:
+		return DataTypeInt16
+	case int32:
+		return DataTypeInt32
+	case int64:
+		return DataTypeInt64
+	case uint8:
+		return DataTypeUint8
+	case uint16:
+		return DataTypeUint16
+	case uint32:
+		return DataTypeUint32
+	case Float16:
+		return DataTypeFloat16
+	case BFloat16:
+		return DataTypeBFloat16
+	}
+	return DataTypeUint64
+}
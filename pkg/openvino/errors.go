@@ -3,8 +3,14 @@ package openvino
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/accretional/openvino-go/internal/cgo"
 )
 
+// Sentinel errors for the operation that failed. Errors returned by this
+// package match them with errors.Is.
 var (
 	ErrDeviceNotFound     = errors.New("openvino: device not found")
 	ErrModelLoadFailed    = errors.New("openvino: failed to load model")
@@ -12,17 +18,160 @@ var (
 	ErrInferenceFailed    = errors.New("openvino: inference failed")
 	ErrInvalidTensor      = errors.New("openvino: invalid tensor")
 	ErrUnsupportedType    = errors.New("openvino: unsupported data type")
+	ErrClosed             = errors.New("openvino: use of closed handle")
+	// ErrInferenceCancelled is returned when the context of an inference
+	// ends before it completes. The error also matches the context's error,
+	// such as context.Canceled.
+	ErrInferenceCancelled = errors.New("openvino: inference cancelled")
+)
+
+// Sentinel errors for the class of failure OpenVINO reported, matching the
+// ErrorCode of an Error.
+var (
+	ErrNotFound          = errors.New("openvino: not found")
+	ErrNotImplemented    = errors.New("openvino: not implemented")
+	ErrParameterMismatch = errors.New("openvino: parameter mismatch")
+	ErrBusy              = errors.New("openvino: request busy")
+	ErrCancelled         = errors.New("openvino: request cancelled")
+)
+
+// ErrorCode classifies the exception OpenVINO raised.
+type ErrorCode int32
+
+const (
+	ErrorCodeGeneral           ErrorCode = ErrorCode(cgo.ErrorCodeGeneral)
+	ErrorCodeNotFound          ErrorCode = ErrorCode(cgo.ErrorCodeNotFound)
+	ErrorCodeNotImplemented    ErrorCode = ErrorCode(cgo.ErrorCodeNotImplemented)
+	ErrorCodeParameterMismatch ErrorCode = ErrorCode(cgo.ErrorCodeParameterMismatch)
+	ErrorCodeBusy              ErrorCode = ErrorCode(cgo.ErrorCodeBusy)
+	ErrorCodeCancelled         ErrorCode = ErrorCode(cgo.ErrorCodeCancelled)
 )
 
+func (c ErrorCode) sentinel() error {
+	switch c {
+	case ErrorCodeNotFound:
+		return ErrNotFound
+	case ErrorCodeNotImplemented:
+		return ErrNotImplemented
+	case ErrorCodeParameterMismatch:
+		return ErrParameterMismatch
+	case ErrorCodeBusy:
+		return ErrBusy
+	case ErrorCodeCancelled:
+		return ErrCancelled
+	}
+	return nil
+}
+
+// Error describes a failed call. Code is zero when the failure was detected
+// in Go before reaching OpenVINO.
 type Error struct {
-	Code    int32
+	Code    ErrorCode
 	Message string
+	Op      string // Method that failed, such as "Core.CompileModel"
+	Port    string // Tensor name, or "#index" for ports addressed by index
+	Device  string
+
+	kind  error
+	cause error
 }
 
 func (e *Error) Error() string {
-	return fmt.Sprintf("openvino error %d: %s", e.Code, e.Message)
+	var b strings.Builder
+	b.WriteString("openvino: ")
+	if e.Op != "" {
+		b.WriteString(e.Op)
+		var details []string
+		if e.Device != "" {
+			details = append(details, "device "+strconv.Quote(e.Device))
+		}
+		if e.Port != "" {
+			details = append(details, "port "+strconv.Quote(e.Port))
+		}
+		if len(details) > 0 {
+			b.WriteString(" (" + strings.Join(details, ", ") + ")")
+		}
+		b.WriteString(": ")
+	}
+	if e.Message != "" {
+		b.WriteString(e.Message)
+	} else {
+		fmt.Fprintf(&b, "error %d", e.Code)
+	}
+	return b.String()
+}
+
+// Unwrap returns the sentinels the error matches: the one for the failed
+// operation, the one for its ErrorCode, and any underlying Go error.
+func (e *Error) Unwrap() []error {
+	var errs []error
+	if e.kind != nil {
+		errs = append(errs, e.kind)
+	}
+	if s := e.Code.sentinel(); s != nil {
+		errs = append(errs, s)
+	}
+	if e.Code == ErrorCodeNotFound && e.Device != "" && strings.Contains(e.Message, e.Device) {
+		errs = append(errs, ErrDeviceNotFound)
+	}
+	if e.cause != nil {
+		errs = append(errs, e.cause)
+	}
+	return errs
+}
+
+// wrapError returns err as an *Error for op, matching kind in addition to the
+// sentinel for its code. It returns nil if err is nil and leaves errors that
+// are already an *Error unchanged.
+func wrapError(err error, kind error, op string) error {
+	return newError(err, kind, op, "", "")
+}
+
+// wrapPortError is wrapError for an operation on the named port.
+func wrapPortError(err error, kind error, op, port string) error {
+	return newError(err, kind, op, port, "")
+}
+
+// wrapIndexError is wrapError for an operation on the port at index.
+func wrapIndexError(err error, kind error, op string, index int32) error {
+	return newError(err, kind, op, indexPort(index), "")
+}
+
+// wrapDeviceError is wrapError for an operation on device.
+func wrapDeviceError(err error, kind error, op, device string) error {
+	return newError(err, kind, op, "", device)
+}
+
+// cancelledError reports that the context of op ended with ctxErr before
+// inference completed.
+func cancelledError(op string, ctxErr error) error {
+	return &Error{Code: ErrorCodeCancelled, Message: ctxErr.Error(), Op: op, kind: ErrInferenceCancelled, cause: ctxErr}
+}
+
+func indexPort(index int32) string {
+	return "#" + strconv.Itoa(int(index))
 }
 
-func (e *Error) Unwrap() error {
-	return fmt.Errorf("openvino error: %s", e.Message)
+func newError(err error, kind error, op, port, device string) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+	e = &Error{Op: op, Port: port, Device: device, kind: kind}
+	var cErr *cgo.Error
+	switch {
+	case errors.As(err, &cErr):
+		e.Code = ErrorCode(cErr.Code)
+		e.Message = cErr.Message
+	case errors.Is(err, cgo.ErrUnsupportedType):
+		e.Message = err.Error()
+		e.cause = ErrUnsupportedType
+	default:
+		e.Message = strings.TrimPrefix(err.Error(), "openvino: ")
+		e.cause = err
+	}
+	return e
 }
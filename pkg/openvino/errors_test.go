@@ -3,26 +3,106 @@ package openvino
 import (
 	"errors"
 	"testing"
+
+	"github.com/accretional/openvino-go/internal/cgo"
 )
 
 func TestError_Error(t *testing.T) {
-	err := &Error{
-		Code:    1,
-		Message: "test error",
+	tests := []struct {
+		err  *Error
+		want string
+	}{
+		{&Error{Code: ErrorCodeGeneral, Message: "test error"}, "openvino: test error"},
+		{&Error{Code: ErrorCodeGeneral}, "openvino: error -1"},
+		{&Error{Message: "bad", Op: "Core.ReadModel"}, "openvino: Core.ReadModel: bad"},
+		{&Error{Message: "bad", Op: "Core.CompileModel", Device: "CPU"}, `openvino: Core.CompileModel (device "CPU"): bad`},
+		{&Error{Message: "bad", Op: "InferRequest.SetTensor", Port: "input"}, `openvino: InferRequest.SetTensor (port "input"): bad`},
 	}
-	if err.Error() == "" {
-		t.Error("Error() should return non-empty string")
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
 	}
 }
 
 func TestError_Unwrap(t *testing.T) {
-	err := &Error{
-		Code:    1,
-		Message: "test error",
+	err := wrapError(&cgo.Error{Code: cgo.ErrorCodeGeneral, Message: "test error"}, ErrModelLoadFailed, "Core.ReadModel")
+	if !errors.Is(err, ErrModelLoadFailed) {
+		t.Error("errors.Is(err, ErrModelLoadFailed) = false")
 	}
-	unwrapped := err.Unwrap()
-	if unwrapped == nil {
-		t.Error("Unwrap() should return non-nil error")
+	if errors.Is(err, ErrModelCompileFailed) || errors.Is(err, ErrNotFound) {
+		t.Error("error matches a sentinel it should not")
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatal("errors.As(err, *Error) = false")
+	}
+	if e.Code != ErrorCodeGeneral || e.Message != "test error" || e.Op != "Core.ReadModel" {
+		t.Errorf("unexpected error fields: %+v", e)
+	}
+}
+
+func TestError_codeSentinels(t *testing.T) {
+	tests := []struct {
+		code int32
+		want error
+	}{
+		{cgo.ErrorCodeNotFound, ErrNotFound},
+		{cgo.ErrorCodeNotImplemented, ErrNotImplemented},
+		{cgo.ErrorCodeParameterMismatch, ErrParameterMismatch},
+		{cgo.ErrorCodeBusy, ErrBusy},
+		{cgo.ErrorCodeCancelled, ErrCancelled},
+	}
+	for _, tt := range tests {
+		err := wrapError(&cgo.Error{Code: tt.code, Message: "failed"}, ErrInferenceFailed, "InferRequest.Infer")
+		if !errors.Is(err, tt.want) {
+			t.Errorf("code %d: errors.Is(err, %v) = false", tt.code, tt.want)
+		}
+		if !errors.Is(err, ErrInferenceFailed) {
+			t.Errorf("code %d: errors.Is(err, ErrInferenceFailed) = false", tt.code)
+		}
+	}
+}
+
+func TestError_deviceNotFound(t *testing.T) {
+	cErr := &cgo.Error{Code: cgo.ErrorCodeNotFound, Message: `Device with "FOO" name is not registered in the OpenVINO Runtime`}
+	err := wrapDeviceError(cErr, ErrModelCompileFailed, "Core.CompileModel", "FOO")
+	if !errors.Is(err, ErrDeviceNotFound) {
+		t.Error("errors.Is(err, ErrDeviceNotFound) = false")
+	}
+	if !errors.Is(err, ErrModelCompileFailed) {
+		t.Error("errors.Is(err, ErrModelCompileFailed) = false")
+	}
+
+	cErr = &cgo.Error{Code: cgo.ErrorCodeNotFound, Message: "port input not found"}
+	err = wrapDeviceError(cErr, ErrModelCompileFailed, "Core.CompileModel", "CPU")
+	if errors.Is(err, ErrDeviceNotFound) {
+		t.Error("a not-found error unrelated to the device matched ErrDeviceNotFound")
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	if err := wrapError(nil, ErrInferenceFailed, "InferRequest.Infer"); err != nil {
+		t.Errorf("wrapError(nil) = %v, want nil", err)
+	}
+
+	inner := wrapPortError(errors.New("boom"), ErrInvalidTensor, "InferRequest.GetTensor", "input")
+	if err := wrapError(inner, ErrInferenceFailed, "Other"); err != inner {
+		t.Errorf("wrapError re-wrapped an *Error: %v", err)
+	}
+
+	goErr := errors.New("tensor cannot be nil")
+	err := wrapIndexError(goErr, ErrInvalidTensor, "InferRequest.SetInputTensorsByIndex", 2)
+	if !errors.Is(err, goErr) || !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("wrapped Go error does not match its cause and kind: %v", err)
+	}
+	if want := `openvino: InferRequest.SetInputTensorsByIndex (port "#2"): tensor cannot be nil`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	err = wrapError(cgo.ErrUnsupportedType, ErrInvalidTensor, "NewTensorWithData")
+	if !errors.Is(err, ErrUnsupportedType) || !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("unsupported type error does not match ErrUnsupportedType: %v", err)
 	}
 }
 
@@ -37,6 +117,11 @@ func TestErrorVariables(t *testing.T) {
 		{"ErrInferenceFailed", ErrInferenceFailed},
 		{"ErrInvalidTensor", ErrInvalidTensor},
 		{"ErrUnsupportedType", ErrUnsupportedType},
+		{"ErrNotFound", ErrNotFound},
+		{"ErrNotImplemented", ErrNotImplemented},
+		{"ErrParameterMismatch", ErrParameterMismatch},
+		{"ErrBusy", ErrBusy},
+		{"ErrCancelled", ErrCancelled},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,178 @@
+package openvino
+
+import "math"
+
+// Float16 is an IEEE 754 half-precision number, the element type of
+// DataTypeFloat16 tensors.
+type Float16 uint16
+
+// BFloat16 is a bfloat16 number, the upper half of a float32, and the
+// element type of DataTypeBFloat16 tensors.
+type BFloat16 uint16
+
+// NewFloat16 converts f to half precision, rounding to nearest even.
+// Values beyond the half-precision range become infinities.
+func NewFloat16(f float32) Float16 {
+	b := math.Float32bits(f)
+	sign := uint32(b>>16) & 0x8000
+	exp := int32(b>>23) & 0xff
+	mant := b & 0x7fffff
+
+	if exp == 0xff {
+		if mant != 0 {
+			return Float16(sign | 0x7e00)
+		}
+		return Float16(sign | 0x7c00)
+	}
+	e := exp - 127 + 15
+	switch {
+	case e >= 0x1f:
+		return Float16(sign | 0x7c00)
+	case e <= 0:
+		if e < -10 {
+			return Float16(sign)
+		}
+		// Subnormal: the implicit leading bit becomes explicit.
+		return Float16(sign | shiftRoundEven(mant|0x800000, uint32(14-e)))
+	}
+	// A mantissa that rounds up carries into the exponent, up to infinity.
+	return Float16(sign | (uint32(e)<<10 + shiftRoundEven(mant, 13)))
+}
+
+// shiftRoundEven shifts v right by s bits, rounding to nearest even.
+func shiftRoundEven(v, s uint32) uint32 {
+	half := uint32(1) << (s - 1)
+	rem := v & (half<<1 - 1)
+	v >>= s
+	if rem > half || (rem == half && v&1 == 1) {
+		v++
+	}
+	return v
+}
+
+// Float32 converts h to float32 exactly.
+func (h Float16) Float32() float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		e := uint32(127 - 14)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		return math.Float32frombits(sign | e<<23 | (mant&0x3ff)<<13)
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// NewBFloat16 converts f to bfloat16, rounding to nearest even.
+func NewBFloat16(f float32) BFloat16 {
+	b := math.Float32bits(f)
+	if b&0x7fffffff > 0x7f800000 {
+		return BFloat16(b>>16 | 0x40)
+	}
+	b += 0x7fff + (b>>16)&1
+	return BFloat16(b >> 16)
+}
+
+// Float32 converts h to float32 exactly.
+func (h BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(h) << 16)
+}
+
+// The slice conversions below avoid calls and data-dependent loops in the
+// common case so the compiler keeps them tight; the scalar conversions are
+// easier to follow but slower.
+
+// ConvertToFloat16 converts src to half precision into dst, rounding to
+// nearest even, and returns the number of elements converted, the minimum of
+// len(dst) and len(src). It gives the same results as NewFloat16.
+func ConvertToFloat16(dst []Float16, src []float32) int {
+	const (
+		f32Inf      = 0xff << 23
+		f16Overflow = (127 + 16) << 23
+		f16MinExp   = (127 - 14) << 23
+		// denormMagic aligns a subnormal result to the low mantissa bits, so
+		// a float32 add performs the rounding.
+		denormMagic = ((127 - 15) + (23 - 10) + 1) << 23
+	)
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, f := range src {
+		b := math.Float32bits(f)
+		sign := b & 0x80000000
+		b ^= sign
+		var h uint32
+		switch {
+		case b >= f16Overflow:
+			h = 0x7c00
+			if b > f32Inf {
+				h = 0x7e00
+			}
+		case b < f16MinExp:
+			h = math.Float32bits(math.Float32frombits(b)+math.Float32frombits(denormMagic)) - denormMagic
+		default:
+			odd := (b >> 13) & 1
+			b -= (127 - 15) << 23
+			h = (b + 0xfff + odd) >> 13
+		}
+		dst[i] = Float16(h | sign>>16)
+	}
+	return n
+}
+
+// ConvertFromFloat16 converts src to float32 into dst and returns the number
+// of elements converted, the minimum of len(dst) and len(src).
+func ConvertFromFloat16(dst []float32, src []Float16) int {
+	const (
+		expMask = 0x7c00 << 13
+		// subnormMagic is 2^-14, the value of the smallest normal half.
+		subnormMagic = 113 << 23
+	)
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, h := range src {
+		b := uint32(h&0x7fff) << 13
+		exp := b & expMask
+		b += (127 - 15) << 23
+		switch exp {
+		case expMask:
+			b += (128 - 16) << 23
+		case 0:
+			b += 1 << 23
+			b = math.Float32bits(math.Float32frombits(b) - math.Float32frombits(subnormMagic))
+		}
+		dst[i] = math.Float32frombits(b | uint32(h&0x8000)<<16)
+	}
+	return n
+}
+
+// ConvertToBFloat16 converts src to bfloat16 into dst, rounding to nearest
+// even, and returns the number of elements converted, the minimum of
+// len(dst) and len(src).
+func ConvertToBFloat16(dst []BFloat16, src []float32) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, f := range src {
+		dst[i] = NewBFloat16(f)
+	}
+	return n
+}
+
+// ConvertFromBFloat16 converts src to float32 into dst and returns the number
+// of elements converted, the minimum of len(dst) and len(src).
+func ConvertFromBFloat16(dst []float32, src []BFloat16) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, h := range src {
+		dst[i] = math.Float32frombits(uint32(h) << 16)
+	}
+	return n
+}
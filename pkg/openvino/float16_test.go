@@ -0,0 +1,132 @@
+package openvino
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFloat16(t *testing.T) {
+	tests := []struct {
+		in   float32
+		want Float16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{0.5, 0x3800},
+		{65504, 0x7bff},
+		{65520, 0x7c00}, // rounds up to infinity
+		{1e10, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+		{6.103515625e-05, 0x0400}, // smallest normal
+		{5.960464477539063e-08, 0x0001},
+		{2.9802322387695312e-08, 0x0000}, // half the smallest subnormal rounds to even
+		{1.0009765625, 0x3c01},
+		{1.00048828125, 0x3c00}, // tie rounds to even
+		{1.00146484375, 0x3c02}, // tie rounds to even
+	}
+	for _, tt := range tests {
+		if got := NewFloat16(tt.in); got != tt.want {
+			t.Errorf("NewFloat16(%g) = %#04x, want %#04x", tt.in, uint16(got), uint16(tt.want))
+		}
+	}
+	if h := NewFloat16(float32(math.NaN())); h&0x7c00 != 0x7c00 || h&0x3ff == 0 {
+		t.Errorf("NewFloat16(NaN) = %#04x, want a NaN", uint16(h))
+	}
+}
+
+func TestFloat16_roundTrip(t *testing.T) {
+	for i := 0; i <= math.MaxUint16; i++ {
+		h := Float16(i)
+		f := h.Float32()
+		if math.IsNaN(float64(f)) {
+			if h&0x7c00 != 0x7c00 || h&0x3ff == 0 {
+				t.Fatalf("%#04x converted to NaN", i)
+			}
+			continue
+		}
+		if back := NewFloat16(f); back != h {
+			t.Fatalf("%#04x -> %g -> %#04x", i, f, uint16(back))
+		}
+	}
+}
+
+func TestBFloat16(t *testing.T) {
+	tests := []struct {
+		in   float32
+		want BFloat16
+	}{
+		{0, 0x0000},
+		{1, 0x3f80},
+		{-2, 0xc000},
+		{3.140625, 0x4049},
+		{float32(math.Inf(1)), 0x7f80},
+		{math.Float32frombits(0x3f808000), 0x3f80}, // tie rounds to even
+		{math.Float32frombits(0x3f818000), 0x3f82}, // tie rounds to even
+		{math.Float32frombits(0x3f808001), 0x3f81},
+	}
+	for _, tt := range tests {
+		if got := NewBFloat16(tt.in); got != tt.want {
+			t.Errorf("NewBFloat16(%g) = %#04x, want %#04x", tt.in, uint16(got), uint16(tt.want))
+		}
+		if got := tt.want.Float32(); got != math.Float32frombits(uint32(tt.want)<<16) {
+			t.Errorf("BFloat16(%#04x).Float32() = %g", uint16(tt.want), got)
+		}
+	}
+	if h := NewBFloat16(float32(math.NaN())); !math.IsNaN(float64(h.Float32())) {
+		t.Errorf("NewBFloat16(NaN) = %#04x, want a NaN", uint16(h))
+	}
+}
+
+func TestConvertToFloat16(t *testing.T) {
+	// Step through float32 bit patterns, covering every exponent and both
+	// signs, plus the exact values from TestNewFloat16.
+	var src []float32
+	for b := uint64(0); b <= math.MaxUint32; b += 0x1003 {
+		src = append(src, math.Float32frombits(uint32(b)))
+	}
+	src = append(src, 65504, 65520, 1.00048828125, 1.00146484375, 2.9802322387695312e-08)
+	dst := make([]Float16, len(src))
+	if n := ConvertToFloat16(dst, src); n != len(src) {
+		t.Fatalf("ConvertToFloat16 converted %d elements, want %d", n, len(src))
+	}
+	for i, f := range src {
+		if want := NewFloat16(f); dst[i] != want {
+			t.Fatalf("ConvertToFloat16(%g) = %#04x, want %#04x", f, uint16(dst[i]), uint16(want))
+		}
+	}
+	if n := ConvertToFloat16(dst[:3], src); n != 3 {
+		t.Errorf("ConvertToFloat16 into a short slice converted %d elements, want 3", n)
+	}
+}
+
+func TestConvertFromFloat16(t *testing.T) {
+	src := make([]Float16, math.MaxUint16+1)
+	for i := range src {
+		src[i] = Float16(i)
+	}
+	dst := make([]float32, len(src))
+	ConvertFromFloat16(dst, src)
+	for i, h := range src {
+		if got, want := math.Float32bits(dst[i]), math.Float32bits(h.Float32()); got != want {
+			t.Fatalf("ConvertFromFloat16(%#04x) = %#08x, want %#08x", i, got, want)
+		}
+	}
+}
+
+func TestConvertBFloat16(t *testing.T) {
+	src := []float32{0, 1, -2, 3.14159, float32(math.Inf(1)), 1e-40}
+	halves := make([]BFloat16, len(src))
+	ConvertToBFloat16(halves, src)
+	back := make([]float32, len(src))
+	ConvertFromBFloat16(back, halves)
+	for i, f := range src {
+		if halves[i] != NewBFloat16(f) {
+			t.Errorf("ConvertToBFloat16(%g) = %#04x, want %#04x", f, uint16(halves[i]), uint16(NewBFloat16(f)))
+		}
+		if back[i] != halves[i].Float32() {
+			t.Errorf("ConvertFromBFloat16(%#04x) = %g, want %g", uint16(halves[i]), back[i], halves[i].Float32())
+		}
+	}
+}
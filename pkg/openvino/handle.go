@@ -0,0 +1,55 @@
+package openvino
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// leakCheck enables finalizer cleanup of handles that are garbage collected
+// without being closed, reporting each one. It is set by
+// OPENVINO_GO_LEAKCHECK=1.
+var leakCheck = os.Getenv("OPENVINO_GO_LEAKCHECK") == "1"
+
+// leakOutput receives leak reports.
+var leakOutput io.Writer = os.Stderr
+
+// trackHandle, with leak checking enabled, installs a finalizer that reports
+// kind and the stack that allocated obj if it becomes unreachable without
+// being closed, then releases it. Close must clear the finalizer. Without
+// leak checking, handles are only released by Close.
+func trackHandle[T any](obj *T, kind string, release func(*T)) *T {
+	if !leakCheck {
+		return obj
+	}
+	stack := callerStack(3)
+	runtime.SetFinalizer(obj, func(obj *T) {
+		fmt.Fprintf(leakOutput, "openvino: %s garbage collected without Close, allocated at:\n%s", kind, stack)
+		release(obj)
+	})
+	return obj
+}
+
+// callerStack formats the stack of the calling goroutine, skipping skip
+// frames.
+func callerStack(skip int) string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// closedError reports a call to op on a closed handle of the given kind.
+func closedError(op, kind string) error {
+	return &Error{Op: op, Message: "use of closed " + kind, cause: ErrClosed}
+}
@@ -0,0 +1,139 @@
+package openvino
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClosedHandles(t *testing.T) {
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Core.GetAvailableDevices", func() error { _, err := (&Core{}).GetAvailableDevices(); return err }},
+		{"Core.ReadModel", func() error { _, err := (&Core{}).ReadModel("model.xml"); return err }},
+		{"Core.CompileModel", func() error { _, err := (&Core{}).CompileModel(&Model{}, "CPU"); return err }},
+		{"Model.GetInputs", func() error { _, err := (&Model{}).GetInputs(); return err }},
+		{"Model.Reshape", func() error { return (&Model{}).Reshape(nil) }},
+		{"CompiledModel.CreateInferRequest", func() error { _, err := (&CompiledModel{}).CreateInferRequest(); return err }},
+		{"CompiledModel.NewAsyncInferQueue", func() error { _, err := (&CompiledModel{}).NewAsyncInferQueue(QueueSize(1)); return err }},
+		{"CompiledModel.NewBatcher", func() error { _, err := (&CompiledModel{}).NewBatcher(); return err }},
+		{"InferRequest.Infer", func() error { return (&InferRequest{}).Infer() }},
+		{"InferRequest.Run", func() error { _, err := (&InferRequest{}).Run(context.Background(), nil); return err }},
+		{"InferRequest.GetOutputTensor", func() error { _, err := (&InferRequest{}).GetOutputTensor("output"); return err }},
+		{"Tensor.GetShape", func() error { _, err := (&Tensor{}).GetShape(); return err }},
+		{"Tensor.GetDataAsFloat32", func() error { _, err := (&Tensor{}).GetDataAsFloat32(); return err }},
+		{"VariableState.GetName", func() error { _, err := (&VariableState{}).GetName(); return err }},
+		{"PrePostProcessor.Build", func() error { _, err := (&PrePostProcessor{}).Build(); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, ErrClosed) {
+				t.Fatalf("got %v, want ErrClosed", err)
+			}
+			var e *Error
+			if !errors.As(err, &e) || e.Op != tt.name {
+				t.Errorf("error %v does not name operation %s", err, tt.name)
+			}
+		})
+	}
+}
+
+func TestClose_idempotent(t *testing.T) {
+	(&Core{}).Close()
+	(&Model{}).Close()
+	(&CompiledModel{}).Close()
+	(&InferRequest{}).Close()
+	(&Tensor{}).Close()
+	(&VariableState{}).Close()
+
+	core := coreAvailable(t)
+	model := readTestIRModel(t, core)
+	core.Close()
+	core.Close()
+	model.Close()
+	model.Close()
+	if _, err := model.GetInputs(); !errors.Is(err, ErrClosed) {
+		t.Errorf("GetInputs after Close: got %v, want ErrClosed", err)
+	}
+}
+
+type leakProbe struct {
+	data *int
+}
+
+func TestTrackHandle_leakCheck(t *testing.T) {
+	var buf bytes.Buffer
+	oldCheck, oldOutput := leakCheck, leakOutput
+	leakCheck, leakOutput = true, &buf
+	defer func() { leakCheck, leakOutput = oldCheck, oldOutput }()
+
+	released := make(chan struct{})
+	func() {
+		trackHandle(&leakProbe{data: new(int)}, "leakProbe", func(*leakProbe) { close(released) })
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-released:
+			report := buf.String()
+			if !strings.Contains(report, "leakProbe garbage collected without Close") {
+				t.Errorf("report missing handle kind:\n%s", report)
+			}
+			if !strings.Contains(report, "TestTrackHandle_leakCheck") {
+				t.Errorf("report missing allocation stack:\n%s", report)
+			}
+			return
+		case <-deadline:
+			t.Fatal("finalizer did not run")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestInferRequest_StartAsync_pinned(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+	model := readTestIRModel(t, core)
+	defer model.Close()
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+	if err := req.SetInputTensor("input", []float32{1, 2, 3, 4}, []int64{1, 4}, DataTypeFloat32); err != nil {
+		t.Fatalf("SetInputTensor failed: %v", err)
+	}
+
+	pinned := func() bool {
+		running.Lock()
+		defer running.Unlock()
+		_, ok := running.requests[req]
+		return ok
+	}
+	if err := req.StartAsync(); err != nil {
+		t.Fatalf("StartAsync failed: %v", err)
+	}
+	if !pinned() {
+		t.Error("request not held while inference runs")
+	}
+	if err := req.Wait(); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if pinned() {
+		t.Error("request still held after Wait")
+	}
+}
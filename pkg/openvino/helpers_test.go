@@ -1,15 +1,17 @@
 package openvino
 
 import (
+	"encoding/binary"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
 )
 
-func shapeToInt64(s []int32) []int64 {
+func shapeToInt64(s PartialShape) []int64 {
 	out := make([]int64, len(s))
-	for i, v := range s {
-		out[i] = int64(v)
+	for i, d := range s {
+		out[i] = d.Length()
 	}
 	return out
 }
@@ -49,3 +51,83 @@ func getTestModelPath(t *testing.T) string {
 	}
 	return path
 }
+
+// testIRModel is a minimal OpenVINO IR model computing output = input + bias
+// for a [1,4] f32 input, with bias read from testIRWeights.
+const testIRModel = `<?xml version="1.0"?>
+<net name="add_bias" version="11">
+	<layers>
+		<layer id="0" name="input" type="Parameter" version="opset1">
+			<data shape="1,4" element_type="f32"/>
+			<output>
+				<port id="0" precision="FP32" names="input">
+					<dim>1</dim>
+					<dim>4</dim>
+				</port>
+			</output>
+		</layer>
+		<layer id="1" name="bias" type="Const" version="opset1">
+			<data element_type="f32" shape="1,4" offset="0" size="16"/>
+			<output>
+				<port id="0" precision="FP32">
+					<dim>1</dim>
+					<dim>4</dim>
+				</port>
+			</output>
+		</layer>
+		<layer id="2" name="add" type="Add" version="opset1">
+			<data auto_broadcast="numpy"/>
+			<input>
+				<port id="0" precision="FP32">
+					<dim>1</dim>
+					<dim>4</dim>
+				</port>
+				<port id="1" precision="FP32">
+					<dim>1</dim>
+					<dim>4</dim>
+				</port>
+			</input>
+			<output>
+				<port id="2" precision="FP32" names="output">
+					<dim>1</dim>
+					<dim>4</dim>
+				</port>
+			</output>
+		</layer>
+		<layer id="3" name="output/sink" type="Result" version="opset1">
+			<input>
+				<port id="0" precision="FP32">
+					<dim>1</dim>
+					<dim>4</dim>
+				</port>
+			</input>
+		</layer>
+	</layers>
+	<edges>
+		<edge from-layer="0" from-port="0" to-layer="2" to-port="0"/>
+		<edge from-layer="1" from-port="0" to-layer="2" to-port="1"/>
+		<edge from-layer="2" from-port="2" to-layer="3" to-port="0"/>
+	</edges>
+</net>
+`
+
+// testIRBias is the bias added by testIRModel.
+var testIRBias = []float32{1, 2, 3, 4}
+
+func testIRWeights() []byte {
+	weights := make([]byte, 4*len(testIRBias))
+	for i, v := range testIRBias {
+		binary.LittleEndian.PutUint32(weights[i*4:], math.Float32bits(v))
+	}
+	return weights
+}
+
+// readTestIRModel loads testIRModel from memory.
+func readTestIRModel(t *testing.T, core *Core) *Model {
+	t.Helper()
+	model, err := core.ReadModelFromBytes([]byte(testIRModel), testIRWeights())
+	if err != nil {
+		t.Fatalf("ReadModelFromBytes failed: %v", err)
+	}
+	return model
+}
@@ -2,73 +2,251 @@ package openvino
 
 import (
 	"context"
-	"errors"
+	"runtime"
+	"sync"
 
 	"github.com/accretional/openvino-go/internal/cgo"
 )
 
 type InferRequest struct {
 	request *cgo.InferRequest
+	// kept holds the tensors set on each port so that Go memory they wrap
+	// stays reachable while the request uses it.
+	kept map[string][]*Tensor
+	// inputs and outputs cache the port metadata used by Run.
+	inputs, outputs []PortInfo
+}
+
+// running holds the requests started by StartAsync until Wait, a WaitFor
+// that sees completion or Close, so that a request whose only reference is
+// the running inference is not finalized while OpenVINO still uses it.
+var running = struct {
+	sync.Mutex
+	requests map[*InferRequest]struct{}
+}{requests: make(map[*InferRequest]struct{})}
+
+func (ir *InferRequest) pin() {
+	running.Lock()
+	defer running.Unlock()
+	running.requests[ir] = struct{}{}
+}
+
+func (ir *InferRequest) unpin() {
+	running.Lock()
+	defer running.Unlock()
+	delete(running.requests, ir)
 }
 
 func (cm *CompiledModel) CreateInferRequest() (*InferRequest, error) {
-	request, err := cm.compiled.CreateInferRequest()
+	const op = "CompiledModel.CreateInferRequest"
+	compiled, err := cm.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &InferRequest{request: request}, nil
+	defer runtime.KeepAlive(cm)
+	request, err := compiled.CreateInferRequest()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return trackHandle(&InferRequest{request: request}, "InferRequest", (*InferRequest).Close), nil
 }
 
+// Close releases the infer request. Tensors obtained from it stay valid. It
+// is safe to call Close more than once, but not while an inference started
+// by StartAsync is still running.
 func (ir *InferRequest) Close() {
 	if ir.request != nil {
+		runtime.SetFinalizer(ir, nil)
+		ir.unpin()
 		ir.request.Destroy()
+		ir.request = nil
+		ir.kept = nil
+		ir.inputs, ir.outputs = nil, nil
+	}
+}
+
+// keep records the tensors set on port, replacing any set before.
+func (ir *InferRequest) keep(port string, tensors ...*Tensor) {
+	if ir.kept == nil {
+		ir.kept = make(map[string][]*Tensor)
+	}
+	ir.kept[port] = append([]*Tensor(nil), tensors...)
+}
+
+// forget drops the tensors kept for the ports of inputs if they are still
+// the ones set there, so that they can be collected once a job is done.
+func (ir *InferRequest) forget(inputs map[string]*Tensor) {
+	for port, tensor := range inputs {
+		if kept := ir.kept[port]; len(kept) == 1 && kept[0] == tensor {
+			delete(ir.kept, port)
+		}
 	}
 }
 
+func (ir *InferRequest) handle(op string) (*cgo.InferRequest, error) {
+	if ir.request == nil {
+		return nil, closedError(op, "InferRequest")
+	}
+	return ir.request, nil
+}
+
+// SetInputTensor copies data into a new tensor of dataType and shape and sets
+// it as the input with the given name. data is a slice of the Go type
+// matching dataType: []bool for DataTypeBoolean, []string for DataTypeString
+// and packed []uint8 for sub-byte types. Half-precision inputs may also be
+// given as []float32, which is converted.
 func (ir *InferRequest) SetInputTensor(name string, data interface{}, shape []int64, dataType DataType) error {
-	return ir.request.SetInputTensor(name, data, shape, dataType)
+	const op = "InferRequest.SetInputTensor"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	if v, ok := data.([]string); ok && dataType == DataTypeString {
+		tensor, err := NewTensorFromStrings(shape, v)
+		if err != nil {
+			return wrapPortError(err, nil, op, name)
+		}
+		defer tensor.Close()
+		return wrapPortError(request.SetTensor(name, tensor.tensor), ErrInvalidTensor, op, name)
+	}
+	data, err = hostData(data, dataType, shape)
+	if err != nil {
+		return wrapPortError(err, ErrInvalidTensor, op, name)
+	}
+	return wrapPortError(request.SetInputTensor(name, data, shape, dataType), ErrInvalidTensor, op, name)
 }
 
 func (ir *InferRequest) SetInputTensorByIndex(index int32, data interface{}, shape []int64, dataType DataType) error {
-	return ir.request.SetInputTensorByIndex(index, data, shape, dataType)
+	const op = "InferRequest.SetInputTensorByIndex"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	if v, ok := data.([]string); ok && dataType == DataTypeString {
+		tensor, err := NewTensorFromStrings(shape, v)
+		if err != nil {
+			return wrapIndexError(err, nil, op, index)
+		}
+		defer tensor.Close()
+		// A batch of one tensor is set like a single tensor.
+		return wrapIndexError(request.SetInputTensorsByIndex(index, []*cgo.Tensor{tensor.tensor}), ErrInvalidTensor, op, index)
+	}
+	data, err = hostData(data, dataType, shape)
+	if err != nil {
+		return wrapIndexError(err, ErrInvalidTensor, op, index)
+	}
+	return wrapIndexError(request.SetInputTensorByIndex(index, data, shape, dataType), ErrInvalidTensor, op, index)
 }
 
 func (ir *InferRequest) Infer() error {
-	return ir.request.Infer()
+	const op = "InferRequest.Infer"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	return wrapError(request.Infer(), ErrInferenceFailed, op)
 }
 
+// InferWithContext runs inference like Infer, but stops it when ctx ends.
+// The inference is then cancelled and the request drained before
+// InferWithContext returns, so the request is idle and can be reused at once;
+// its outputs are undefined. The error matches ErrInferenceCancelled and the
+// context's error. An inference that completes as ctx ends is reported as
+// completed. Like Infer, it does not call the callback set with SetCallback.
 func (ir *InferRequest) InferWithContext(ctx context.Context) error {
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
+	const op = "InferRequest.InferWithContext"
+	if err := ctx.Err(); err != nil {
+		return cancelledError(op, err)
+	}
+	if ctx.Done() == nil {
+		// The context can never end.
+		return ir.Infer()
 	}
+	return ir.inferContext(ctx, op)
+}
 
-	err := ir.request.Infer()
+// inferContext runs synchronous inference with waitContext.
+func (ir *InferRequest) inferContext(ctx context.Context, op string) error {
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	return waitContext(ctx, request, request.Infer, op)
+}
 
+// waitContext calls wait, which runs or waits for an inference, until it
+// returns or ctx ends. In that case it cancels the inference and waits for
+// the request to become idle.
+func waitContext(ctx context.Context, request *cgo.InferRequest, wait func() error, op string) error {
+	if ctx.Done() == nil {
+		return wrapError(wait(), ErrInferenceFailed, op)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- wait()
+	}()
 	select {
+	case err := <-done:
+		return wrapError(err, ErrInferenceFailed, op)
 	case <-ctx.Done():
-		return ctx.Err()
-	default:
-		return err
+		// wait returns once the cancelled request is idle again, with an
+		// error if the inference was interrupted.
+		request.Cancel()
+		if err := <-done; err == nil {
+			// The inference completed before it could be cancelled.
+			return nil
+		}
+		return cancelledError(op, ctx.Err())
 	}
 }
 
 // StartAsync starts asynchronous inference. The inference runs in the background.
-// Use Wait() or WaitFor() to wait for completion.
+// Use Wait() or WaitFor() to wait for completion. The request stays
+// reachable until then, even if the caller drops it.
 func (ir *InferRequest) StartAsync() error {
-	return ir.request.StartAsync()
+	const op = "InferRequest.StartAsync"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	if err := request.StartAsync(); err != nil {
+		return wrapError(err, ErrInferenceFailed, op)
+	}
+	ir.pin()
+	return nil
 }
 
 // Wait waits for asynchronous inference to complete. This blocks until inference is done.
 func (ir *InferRequest) Wait() error {
-	return ir.request.Wait()
+	const op = "InferRequest.Wait"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	defer ir.unpin()
+	return wrapError(request.Wait(), ErrInferenceFailed, op)
 }
 
 // WaitFor waits for asynchronous inference to complete with a timeout.
 // Returns true if inference completed, false if timeout occurred.
 func (ir *InferRequest) WaitFor(timeoutMs int64) (bool, error) {
-	return ir.request.WaitFor(timeoutMs)
+	const op = "InferRequest.WaitFor"
+	request, err := ir.handle(op)
+	if err != nil {
+		return false, err
+	}
+	defer runtime.KeepAlive(ir)
+	done, err := request.WaitFor(timeoutMs)
+	if done {
+		ir.unpin()
+	}
+	return done, wrapError(err, ErrInferenceFailed, op)
 }
 
 // InferAsync starts asynchronous inference and waits for completion.
@@ -80,104 +258,202 @@ func (ir *InferRequest) InferAsync() error {
 	return ir.Wait()
 }
 
-// InferAsyncWithContext starts asynchronous inference and waits for completion with context support.
-// This is a convenience method that combines StartAsync() and Wait() with context cancellation.
+// InferAsyncWithContext starts asynchronous inference and waits for it to
+// complete or for ctx to end. As with InferWithContext, an inference whose
+// context ends is cancelled and the request drained before returning, and
+// the error matches ErrInferenceCancelled and the context's error. Like
+// StartAsync, it calls the callback set with SetCallback on completion.
 func (ir *InferRequest) InferAsyncWithContext(ctx context.Context) error {
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
+	const op = "InferRequest.InferAsyncWithContext"
+	if err := ctx.Err(); err != nil {
+		return cancelledError(op, err)
 	}
-
-	if err := ir.StartAsync(); err != nil {
+	request, err := ir.handle(op)
+	if err != nil {
 		return err
 	}
-
-	// Wait for completion, checking context periodically
-	done := make(chan error, 1)
-	go func() {
-		done <- ir.Wait()
-	}()
-
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case err := <-done:
-		return err
+	defer runtime.KeepAlive(ir)
+	if err := request.StartAsync(); err != nil {
+		return wrapError(err, ErrInferenceFailed, op)
 	}
+	return waitContext(ctx, request, request.Wait, op)
 }
 
 // GetInputTensor retrieves an input tensor by name.
 func (ir *InferRequest) GetInputTensor(name string) (*Tensor, error) {
-	tensor, err := ir.request.GetInputTensor(name)
+	const op = "InferRequest.GetInputTensor"
+	request, err := ir.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &Tensor{tensor: tensor}, nil
+	defer runtime.KeepAlive(ir)
+	tensor, err := request.GetInputTensor(name)
+	if err != nil {
+		return nil, wrapPortError(err, ErrInvalidTensor, op, name)
+	}
+	return newTensor(tensor), nil
 }
 
 // GetInputTensorByIndex retrieves an input tensor by index.
 func (ir *InferRequest) GetInputTensorByIndex(index int32) (*Tensor, error) {
-	tensor, err := ir.request.GetInputTensorByIndex(index)
+	const op = "InferRequest.GetInputTensorByIndex"
+	request, err := ir.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &Tensor{tensor: tensor}, nil
+	defer runtime.KeepAlive(ir)
+	tensor, err := request.GetInputTensorByIndex(index)
+	if err != nil {
+		return nil, wrapIndexError(err, ErrInvalidTensor, op, index)
+	}
+	return newTensor(tensor), nil
 }
 
 // SetInputTensors sets a batch of input tensors by name.
 // Requires model with batch dimension; number of tensors must match batch size.
 func (ir *InferRequest) SetInputTensors(name string, tensors []*Tensor) error {
-	cgoTensors := make([]*cgo.Tensor, len(tensors))
-	for i, t := range tensors {
-		if t == nil {
-			return errors.New("tensor cannot be nil")
-		}
-		cgoTensors[i] = t.tensor
+	const op = "InferRequest.SetInputTensors"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	cgoTensors, err := tensorArgs(tensors, op, name)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	defer runtime.KeepAlive(tensors)
+	if err := request.SetInputTensors(name, cgoTensors); err != nil {
+		return wrapPortError(err, ErrInvalidTensor, op, name)
 	}
-	return ir.request.SetInputTensors(name, cgoTensors)
+	ir.keep(name, tensors...)
+	return nil
 }
 
 // SetInputTensorsByIndex sets a batch of input tensors by index.
 // Requires model with batch dimension; number of tensors must match batch size.
 func (ir *InferRequest) SetInputTensorsByIndex(index int32, tensors []*Tensor) error {
-	cgoTensors := make([]*cgo.Tensor, len(tensors))
-	for i, t := range tensors {
-		if t == nil {
-			return errors.New("tensor cannot be nil")
-		}
-		cgoTensors[i] = t.tensor
+	const op = "InferRequest.SetInputTensorsByIndex"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	cgoTensors, err := tensorArgs(tensors, op, indexPort(index))
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	defer runtime.KeepAlive(tensors)
+	if err := request.SetInputTensorsByIndex(index, cgoTensors); err != nil {
+		return wrapIndexError(err, ErrInvalidTensor, op, index)
 	}
-	return ir.request.SetInputTensorsByIndex(index, cgoTensors)
+	ir.keep("input"+indexPort(index), tensors...)
+	return nil
 }
 
 // SetOutputTensor pre-allocates an output tensor by name for zero-copy output.
 func (ir *InferRequest) SetOutputTensor(name string, tensor *Tensor) error {
-	return ir.request.SetOutputTensor(name, tensor.tensor)
+	const op = "InferRequest.SetOutputTensor"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	t, err := tensorArg(tensor, op, name)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	defer runtime.KeepAlive(tensor)
+	if err := request.SetOutputTensor(name, t); err != nil {
+		return wrapPortError(err, ErrInvalidTensor, op, name)
+	}
+	ir.keep(name, tensor)
+	return nil
 }
 
 // SetOutputTensorByIndex pre-allocates an output tensor by index for zero-copy output.
 func (ir *InferRequest) SetOutputTensorByIndex(index int32, tensor *Tensor) error {
-	return ir.request.SetOutputTensorByIndex(index, tensor.tensor)
+	const op = "InferRequest.SetOutputTensorByIndex"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	t, err := tensorArg(tensor, op, indexPort(index))
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	defer runtime.KeepAlive(tensor)
+	if err := request.SetOutputTensorByIndex(index, t); err != nil {
+		return wrapIndexError(err, ErrInvalidTensor, op, index)
+	}
+	ir.keep("output"+indexPort(index), tensor)
+	return nil
 }
 
 func (ir *InferRequest) Cancel() error {
-	return ir.request.Cancel()
+	const op = "InferRequest.Cancel"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	return wrapError(request.Cancel(), nil, op)
 }
 
 func (ir *InferRequest) GetTensor(name string) (*Tensor, error) {
-	tensor, err := ir.request.GetTensor(name)
+	const op = "InferRequest.GetTensor"
+	request, err := ir.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &Tensor{tensor: tensor}, nil
+	defer runtime.KeepAlive(ir)
+	tensor, err := request.GetTensor(name)
+	if err != nil {
+		return nil, wrapPortError(err, ErrInvalidTensor, op, name)
+	}
+	return newTensor(tensor), nil
 }
 
 func (ir *InferRequest) SetTensor(name string, tensor *Tensor) error {
-	return ir.request.SetTensor(name, tensor.tensor)
+	const op = "InferRequest.SetTensor"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	t, err := tensorArg(tensor, op, name)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	defer runtime.KeepAlive(tensor)
+	if err := request.SetTensor(name, t); err != nil {
+		return wrapPortError(err, ErrInvalidTensor, op, name)
+	}
+	ir.keep(name, tensor)
+	return nil
 }
 
-// SetCallback sets a callback function that is called when async inference completes.
+// SetCallback sets a function that is called each time asynchronous
+// inference started with StartAsync, InferAsync or InferAsyncWithContext
+// completes, with nil or the inference error. Synchronous inference through
+// Infer, InferWithContext or Run does not call it. The callback stays
+// set across runs until it is replaced, cleared with nil or the request is
+// closed, and may be changed between runs. It runs on its own goroutine, so
+// it may block or start the next inference; a panic in it is recovered and
+// logged.
 func (ir *InferRequest) SetCallback(callback func(error)) error {
-	return ir.request.SetCallback(callback)
+	const op = "InferRequest.SetCallback"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	if callback == nil {
+		return wrapError(request.SetCallback(nil), nil, op)
+	}
+	err = request.SetCallback(func(err error) {
+		callback(wrapError(err, ErrInferenceFailed, "InferRequest.StartAsync"))
+	})
+	return wrapError(err, nil, op)
 }
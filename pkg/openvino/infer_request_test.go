@@ -2,6 +2,7 @@ package openvino
 
 import (
 	"context"
+	"errors"
 	"testing"
 )
 
@@ -44,7 +45,7 @@ func TestInferRequest_SetInputTensor(t *testing.T) {
 	}
 	size := int64(1)
 	for _, d := range shape {
-		size *= int64(d)
+		size *= d.Length()
 	}
 	data := make([]float32, size)
 	err = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -81,7 +82,7 @@ func TestInferRequest_Infer(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -121,7 +122,7 @@ func TestInferRequest_InferWithContext(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -159,8 +160,8 @@ func TestInferRequest_InferWithContext_cancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 	err = req.InferWithContext(ctx)
-	if err != context.Canceled {
-		t.Errorf("InferWithContext with cancelled context: got %v, want context.Canceled", err)
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, ErrInferenceCancelled) {
+		t.Errorf("InferWithContext with cancelled context: got %v, want context.Canceled and ErrInferenceCancelled", err)
 	}
 }
 
@@ -192,7 +193,7 @@ func TestInferRequest_StartAsync_Wait(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -239,7 +240,7 @@ func TestInferRequest_WaitFor(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -289,7 +290,7 @@ func TestInferRequest_InferAsync(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -330,7 +331,7 @@ func TestInferRequest_InferAsyncWithContext(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -375,7 +376,7 @@ func TestInferRequest_GetInputTensor(t *testing.T) {
 	shape := inputs[0].Shape
 	size := int64(1)
 	for _, d := range shape {
-		size *= int64(d)
+		size *= d.Length()
 	}
 	data := make([]float32, size)
 	for i := range data {
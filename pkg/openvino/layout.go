@@ -0,0 +1,166 @@
+package openvino
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Layout names the dimensions of a tensor, such as "NCHW", "NC" or "?C??".
+// Single-letter names may be written without brackets; longer names need the
+// bracketed form "[N,SEQ,C]". "?" is an unnamed dimension and "..." stands
+// for any number of them. Names are case-insensitive.
+type Layout string
+
+const (
+	LayoutNCHW Layout = "NCHW"
+	LayoutNHWC Layout = "NHWC"
+	LayoutNC   Layout = "NC"
+)
+
+const layoutEllipsis = "..."
+
+// ParseLayout validates s and returns it in canonical form: upper case, and
+// without brackets when every name is a single letter.
+func ParseLayout(s string) (Layout, error) {
+	dims, err := parseLayoutDims(s)
+	if err != nil {
+		return "", err
+	}
+	return formatLayout(dims), nil
+}
+
+func parseLayoutDims(s string) ([]string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	var dims []string
+	if strings.HasPrefix(s, "[") {
+		if !strings.HasSuffix(s, "]") {
+			return nil, fmt.Errorf("openvino: layout %q is missing a closing bracket", s)
+		}
+		inner := strings.TrimSpace(s[1 : len(s)-1])
+		if inner != "" {
+			for _, f := range strings.Split(inner, ",") {
+				dims = append(dims, strings.ToUpper(strings.TrimSpace(f)))
+			}
+		}
+	} else {
+		for rest := s; rest != ""; {
+			if strings.HasPrefix(rest, layoutEllipsis) {
+				dims = append(dims, layoutEllipsis)
+				rest = rest[len(layoutEllipsis):]
+				continue
+			}
+			dims = append(dims, strings.ToUpper(rest[:1]))
+			rest = rest[1:]
+		}
+	}
+
+	seen := make(map[string]bool, len(dims))
+	for _, d := range dims {
+		switch {
+		case d == "?":
+			continue
+		case d == layoutEllipsis:
+			if seen[d] {
+				return nil, fmt.Errorf("openvino: layout %q has more than one ellipsis", s)
+			}
+		case !isLayoutName(d):
+			return nil, fmt.Errorf("openvino: layout %q has invalid dimension name %q", s, d)
+		case seen[d]:
+			return nil, fmt.Errorf("openvino: layout %q repeats dimension %q", s, d)
+		}
+		seen[d] = true
+	}
+	return dims, nil
+}
+
+func isLayoutName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		letter := r >= 'A' && r <= 'Z'
+		if !letter && !(i > 0 && (r == '_' || (r >= '0' && r <= '9'))) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatLayout(dims []string) Layout {
+	simple := true
+	for _, d := range dims {
+		if len(d) != 1 && d != layoutEllipsis {
+			simple = false
+			break
+		}
+	}
+	if simple {
+		return Layout(strings.Join(dims, ""))
+	}
+	return Layout("[" + strings.Join(dims, ",") + "]")
+}
+
+// String returns the layout as written.
+func (l Layout) String() string {
+	return string(l)
+}
+
+// Index returns the position of the named dimension. Dimensions after an
+// ellipsis are counted from the end, so "...C" gives -1 for "C". ok is false
+// if the layout is invalid or has no such dimension.
+func (l Layout) Index(name string) (idx int, ok bool) {
+	dims, err := parseLayoutDims(string(l))
+	if err != nil {
+		return 0, false
+	}
+	name = strings.ToUpper(name)
+	for i, d := range dims {
+		if d == layoutEllipsis {
+			for j := len(dims) - 1; j > i; j-- {
+				if dims[j] == name {
+					return j - len(dims), true
+				}
+			}
+			return 0, false
+		}
+		if d == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Has reports whether the layout names the dimension.
+func (l Layout) Has(name string) bool {
+	_, ok := l.Index(name)
+	return ok
+}
+
+// BatchIdx returns the index of the batch dimension N.
+func (l Layout) BatchIdx() (int, bool) { return l.Index("N") }
+
+// ChannelsIdx returns the index of the channels dimension C.
+func (l Layout) ChannelsIdx() (int, bool) { return l.Index("C") }
+
+// DepthIdx returns the index of the depth dimension D.
+func (l Layout) DepthIdx() (int, bool) { return l.Index("D") }
+
+// HeightIdx returns the index of the height dimension H.
+func (l Layout) HeightIdx() (int, bool) { return l.Index("H") }
+
+// WidthIdx returns the index of the width dimension W.
+func (l Layout) WidthIdx() (int, bool) { return l.Index("W") }
+
+// layoutFromCgo normalizes a layout reported by OpenVINO, which always uses
+// the bracketed form.
+func layoutFromCgo(s string) Layout {
+	l, err := ParseLayout(s)
+	if err != nil {
+		return Layout(s)
+	}
+	return l
+}
@@ -0,0 +1,66 @@
+package openvino
+
+import "testing"
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Layout
+	}{
+		{"NCHW", "NCHW"},
+		{"nhwc", "NHWC"},
+		{"?C??", "?C??"},
+		{"[N,C,H,W]", "NCHW"},
+		{"[n, seq, c]", "[N,SEQ,C]"},
+		{"N...C", "N...C"},
+		{"[N,...,C]", "N...C"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := ParseLayout(tt.in)
+		if err != nil {
+			t.Errorf("ParseLayout(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLayout(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"NCHN", "N1", "[N,C", "......", "[N,,C]", "[1N]"} {
+		if _, err := ParseLayout(in); err == nil {
+			t.Errorf("ParseLayout(%q) should fail", in)
+		}
+	}
+}
+
+func TestLayout_Index(t *testing.T) {
+	tests := []struct {
+		layout Layout
+		idx    func(Layout) (int, bool)
+		want   int
+		ok     bool
+	}{
+		{LayoutNCHW, Layout.BatchIdx, 0, true},
+		{LayoutNCHW, Layout.ChannelsIdx, 1, true},
+		{LayoutNHWC, Layout.HeightIdx, 1, true},
+		{LayoutNHWC, Layout.WidthIdx, 2, true},
+		{LayoutNHWC, Layout.ChannelsIdx, 3, true},
+		{"?C??", Layout.ChannelsIdx, 1, true},
+		{"?C??", Layout.BatchIdx, 0, false},
+		{"...C", Layout.ChannelsIdx, -1, true},
+		{"N...HW", Layout.HeightIdx, -2, true},
+		{"[n,d,c]", Layout.DepthIdx, 1, true},
+		{"NCHN", Layout.BatchIdx, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.idx(tt.layout)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("%q: index = %d, %v; want %d, %v", tt.layout, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if !Layout("[N,SEQ,C]").Has("seq") {
+		t.Error(`Has("seq") = false, want true`)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build !unix
+
+package openvino
+
+import (
+	"io"
+	"os"
+	"runtime"
+)
+
+// mapFile reads size bytes of f into memory, pinned so that tensors can
+// use it, on platforms without mmap support.
+func mapFile(f *os.File, size int64) (data []byte, release func() error, err error) {
+	data = make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, nil, err
+	}
+	if size == 0 {
+		return data, func() error { return nil }, nil
+	}
+	pinner := new(runtime.Pinner)
+	pinner.Pin(&data[0])
+	return data, func() error {
+		pinner.Unpin()
+		return nil
+	}, nil
+}
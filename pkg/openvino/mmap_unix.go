@@ -0,0 +1,22 @@
+//go:build unix
+
+package openvino
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps size bytes of f into memory. The mapping is private and
+// writable: writes through tensors over it are copied on write and never
+// reach the file.
+func mapFile(f *os.File, size int64) (data []byte, release func() error, err error) {
+	if size == 0 {
+		return []byte{}, func() error { return nil }, nil
+	}
+	data, err = syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -1,55 +1,252 @@
 package openvino
 
-import "github.com/accretional/openvino-go/internal/cgo"
+import (
+	"runtime"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
 
 type Model struct {
 	model *cgo.Model
 }
 
+func newModel(model *cgo.Model) *Model {
+	return trackHandle(&Model{model: model}, "Model", (*Model).Close)
+}
+
 func (c *Core) ReadModel(modelPath string) (*Model, error) {
-	model, err := c.core.ReadModel(modelPath)
+	const op = "Core.ReadModel"
+	core, err := c.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(c)
+	model, err := core.ReadModel(modelPath)
+	if err != nil {
+		return nil, wrapError(err, ErrModelLoadFailed, op)
+	}
+	return newModel(model), nil
+}
+
+// ReadModelWithWeights reads an OpenVINO IR model whose weights file is not
+// stored next to the .xml file under the same base name.
+func (c *Core) ReadModelWithWeights(xmlPath, binPath string) (*Model, error) {
+	const op = "Core.ReadModelWithWeights"
+	core, err := c.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &Model{model: model}, nil
+	defer runtime.KeepAlive(c)
+	model, err := core.ReadModelWithWeights(xmlPath, binPath)
+	if err != nil {
+		return nil, wrapError(err, ErrModelLoadFailed, op)
+	}
+	return newModel(model), nil
+}
+
+// ReadModelFromBytes reads a model held in memory, such as an embedded or
+// decrypted file. model holds an IR .xml document or an ONNX model; weights
+// holds the IR .bin contents and is nil for ONNX. The weights are copied, so
+// the caller may reuse both buffers once ReadModelFromBytes returns.
+func (c *Core) ReadModelFromBytes(model []byte, weights []byte) (*Model, error) {
+	const op = "Core.ReadModelFromBytes"
+	core, err := c.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(c)
+	m, err := core.ReadModelFromMemory(model, weights)
+	if err != nil {
+		return nil, wrapError(err, ErrModelLoadFailed, op)
+	}
+	return newModel(m), nil
 }
 
+// Close releases the model. Compiled models created from it stay valid. It
+// is safe to call Close more than once.
 func (m *Model) Close() {
 	if m.model != nil {
+		runtime.SetFinalizer(m, nil)
 		m.model.Destroy()
+		m.model = nil
 	}
 }
 
-func (m *Model) GetInputs() ([]PortInfo, error) {
-	cgoPorts, err := m.model.GetInputs()
+func (m *Model) handle(op string) (*cgo.Model, error) {
+	if m.model == nil {
+		return nil, closedError(op, "Model")
+	}
+	return m.model, nil
+}
+
+// Save serializes the model to OpenVINO IR. The weights are written next to
+// xmlPath with a .bin extension. Weights are stored at full precision unless
+// CompressToFP16(true) is given.
+func (m *Model) Save(xmlPath string, options ...SaveOption) error {
+	const op = "Model.Save"
+	model, err := m.handle(op)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer runtime.KeepAlive(m)
+	var cfg saveConfig
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	return wrapError(model.Save(xmlPath, cfg.compressToFP16), nil, op)
+}
 
-	ports := make([]PortInfo, len(cgoPorts))
-	for i, p := range cgoPorts {
-		ports[i] = PortInfo{
-			Name:     p.Name,
-			Shape:    p.Shape,
-			DataType: DataType(p.DataType),
+// Reshape changes the shapes of the inputs named in shapes. Dimensions may be
+// static, dynamic or bounded; the new shapes propagate through the model, so
+// it must be recompiled afterwards.
+func (m *Model) Reshape(shapes map[string]PartialShape) error {
+	const op = "Model.Reshape"
+	model, err := m.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(m)
+	names := make([]string, 0, len(shapes))
+	mins := make([][]int64, 0, len(shapes))
+	maxs := make([][]int64, 0, len(shapes))
+	for name, ps := range shapes {
+		lo, hi, err := ps.bounds()
+		if err != nil {
+			return wrapPortError(err, ErrParameterMismatch, op, name)
 		}
+		names = append(names, name)
+		mins = append(mins, lo)
+		maxs = append(maxs, hi)
 	}
-	return ports, nil
+	return wrapError(model.Reshape(names, mins, maxs), nil, op)
+}
+
+// ReshapeByIndex is like Reshape but addresses inputs by index.
+func (m *Model) ReshapeByIndex(shapes map[int32]PartialShape) error {
+	const op = "Model.ReshapeByIndex"
+	model, err := m.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(m)
+	indices := make([]int32, 0, len(shapes))
+	mins := make([][]int64, 0, len(shapes))
+	maxs := make([][]int64, 0, len(shapes))
+	for index, ps := range shapes {
+		lo, hi, err := ps.bounds()
+		if err != nil {
+			return wrapIndexError(err, ErrParameterMismatch, op, index)
+		}
+		indices = append(indices, index)
+		mins = append(mins, lo)
+		maxs = append(maxs, hi)
+	}
+	return wrapError(model.ReshapeByIndex(indices, mins, maxs), nil, op)
+}
+
+func (m *Model) GetInputs() ([]PortInfo, error) {
+	const op = "Model.GetInputs"
+	model, err := m.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(m)
+	cgoPorts, err := model.GetInputs()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return portInfoFromCgo(cgoPorts), nil
 }
 
 func (m *Model) GetOutputs() ([]PortInfo, error) {
-	cgoPorts, err := m.model.GetOutputs()
+	const op = "Model.GetOutputs"
+	model, err := m.handle(op)
 	if err != nil {
 		return nil, err
 	}
+	defer runtime.KeepAlive(m)
+	cgoPorts, err := model.GetOutputs()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return portInfoFromCgo(cgoPorts), nil
+}
 
+func portInfoFromCgo(cgoPorts []cgo.PortInfo) []PortInfo {
 	ports := make([]PortInfo, len(cgoPorts))
 	for i, p := range cgoPorts {
 		ports[i] = PortInfo{
 			Name:     p.Name,
-			Shape:    p.Shape,
+			Shape:    partialShapeFromCgo(p.Dims),
 			DataType: DataType(p.DataType),
+			Layout:   layoutFromCgo(p.Layout),
 		}
 	}
-	return ports, nil
+	return ports
+}
+
+// SetLayout sets the layout of the input or output with the given tensor
+// name. Pass an empty layout to clear it.
+func (m *Model) SetLayout(port string, layout Layout) error {
+	const op = "Model.SetLayout"
+	model, err := m.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(m)
+	l, err := ParseLayout(string(layout))
+	if err != nil {
+		return wrapPortError(err, ErrParameterMismatch, op, port)
+	}
+	return wrapPortError(model.SetLayout(port, string(l)), nil, op, port)
+}
+
+// GetLayout returns the layout of the input or output with the given tensor
+// name, or an empty layout if none was set.
+func (m *Model) GetLayout(port string) (Layout, error) {
+	inputs, err := m.GetInputs()
+	if err != nil {
+		return "", err
+	}
+	outputs, err := m.GetOutputs()
+	if err != nil {
+		return "", err
+	}
+	for _, p := range append(inputs, outputs...) {
+		if p.Name == port {
+			return p.Layout, nil
+		}
+	}
+	return "", &Error{Code: ErrorCodeNotFound, Message: "model has no such port", Op: "Model.GetLayout", Port: port}
+}
+
+// GetBatch returns the batch dimension, found through the N dimension of the
+// input layouts. It fails if no input has a layout with N.
+func (m *Model) GetBatch() (Dimension, error) {
+	const op = "Model.GetBatch"
+	model, err := m.handle(op)
+	if err != nil {
+		return Dimension{}, err
+	}
+	defer runtime.KeepAlive(m)
+	d, err := model.GetBatch()
+	if err != nil {
+		return Dimension{}, wrapError(err, nil, op)
+	}
+	return Dimension{Min: d.Min, Max: d.Max}, nil
+}
+
+// SetBatch reshapes the model so that the N dimension of every input is
+// batch. Inputs need a layout with N; see SetLayout.
+func (m *Model) SetBatch(batch Dimension) error {
+	const op = "Model.SetBatch"
+	model, err := m.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(m)
+	if err := batch.validate(); err != nil {
+		return wrapError(err, ErrParameterMismatch, op)
+	}
+	return wrapError(model.SetBatch(cgo.Dimension{Min: batch.Min, Max: batch.Max}), nil, op)
 }
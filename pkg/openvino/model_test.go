@@ -1,6 +1,11 @@
 package openvino
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestCore_ReadModel(t *testing.T) {
 	core := coreAvailable(t)
@@ -10,6 +15,9 @@ func TestCore_ReadModel(t *testing.T) {
 	if err == nil {
 		t.Fatal("ReadModel with nonexistent path should return error")
 	}
+	if !errors.Is(err, ErrModelLoadFailed) {
+		t.Errorf("ReadModel error %v does not match ErrModelLoadFailed", err)
+	}
 	// With a real path we'd get model; skip integration test without a fixture
 }
 
@@ -81,3 +89,317 @@ func TestModel_GetOutputs(t *testing.T) {
 		t.Fatal("GetOutputs returned nil slice")
 	}
 }
+
+func TestCore_ReadModelFromBytes(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	inputs, err := model.GetInputs()
+	if err != nil {
+		t.Fatalf("GetInputs failed: %v", err)
+	}
+	if len(inputs) != 1 || inputs[0].Name != "input" {
+		t.Fatalf("inputs = %+v, want a single port named input", inputs)
+	}
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	if err := req.SetInputTensor("input", []float32{10, 20, 30, 40}, []int64{1, 4}, DataTypeFloat32); err != nil {
+		t.Fatalf("SetInputTensor failed: %v", err)
+	}
+	if err := req.Infer(); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	out, err := req.GetOutputTensor("output")
+	if err != nil {
+		t.Fatalf("GetOutputTensor failed: %v", err)
+	}
+	defer out.Close()
+	got, err := out.GetDataAsFloat32()
+	if err != nil {
+		t.Fatalf("GetDataAsFloat32 failed: %v", err)
+	}
+	want := []float32{11, 22, 33, 44}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("output[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCore_ReadModelFromBytes_invalid(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	if _, err := core.ReadModelFromBytes(nil, nil); err == nil {
+		t.Error("ReadModelFromBytes with empty model should return error")
+	}
+	if _, err := core.ReadModelFromBytes([]byte("<net>"), nil); err == nil {
+		t.Error("ReadModelFromBytes with malformed model should return error")
+	}
+}
+
+func TestCore_ReadModelFromBytes_onnx(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	modelPath := getTestModelPath(t)
+	if modelPath == "" || filepath.Ext(modelPath) != ".onnx" {
+		t.Skip("no ONNX test model path")
+	}
+	data, err := os.ReadFile(modelPath)
+	if err != nil {
+		t.Fatalf("read model: %v", err)
+	}
+	model, err := core.ReadModelFromBytes(data, nil)
+	if err != nil {
+		t.Fatalf("ReadModelFromBytes(onnx) failed: %v", err)
+	}
+	model.Close()
+}
+
+func TestCore_ReadModelWithWeights(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	dir := t.TempDir()
+	xmlPath := filepath.Join(dir, "model.xml")
+	binPath := filepath.Join(dir, "weights", "other-name.bin")
+	if err := os.WriteFile(xmlPath, []byte(testIRModel), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(binPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(binPath, testIRWeights(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	model, err := core.ReadModelWithWeights(xmlPath, binPath)
+	if err != nil {
+		t.Fatalf("ReadModelWithWeights failed: %v", err)
+	}
+	model.Close()
+
+	if _, err := core.ReadModelWithWeights(xmlPath, filepath.Join(dir, "missing.bin")); err == nil {
+		t.Error("ReadModelWithWeights with missing weights should return error")
+	}
+}
+
+func TestModel_Save(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	dir := t.TempDir()
+	xmlPath := filepath.Join(dir, "saved.xml")
+	if err := model.Save(xmlPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	full, err := os.Stat(filepath.Join(dir, "saved.bin"))
+	if err != nil {
+		t.Fatalf("Save did not write weights: %v", err)
+	}
+
+	reloaded, err := core.ReadModel(xmlPath)
+	if err != nil {
+		t.Fatalf("ReadModel of saved model failed: %v", err)
+	}
+	defer reloaded.Close()
+	inputs, err := reloaded.GetInputs()
+	if err != nil {
+		t.Fatalf("GetInputs failed: %v", err)
+	}
+	if len(inputs) != 1 || inputs[0].Name != "input" {
+		t.Errorf("reloaded inputs = %+v, want a single port named input", inputs)
+	}
+
+	compressedPath := filepath.Join(dir, "compressed.xml")
+	if err := model.Save(compressedPath, CompressToFP16(true)); err != nil {
+		t.Fatalf("Save with CompressToFP16 failed: %v", err)
+	}
+	compressed, err := os.Stat(filepath.Join(dir, "compressed.bin"))
+	if err != nil {
+		t.Fatalf("Save with CompressToFP16 did not write weights: %v", err)
+	}
+	if compressed.Size() > full.Size() {
+		t.Errorf("compressed weights are %d bytes, larger than uncompressed %d bytes", compressed.Size(), full.Size())
+	}
+}
+
+func TestModel_Save_invalidPath(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	// A regular file where the parent directory should be
+	notDir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notDir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.Save(filepath.Join(notDir, "model.xml")); err == nil {
+		t.Error("Save under a non-directory should return error")
+	}
+}
+
+func TestModel_Reshape(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	if err := model.Reshape(map[string]PartialShape{"input": {Dim(3), Dim(4)}}); err != nil {
+		t.Fatalf("Reshape to static shape failed: %v", err)
+	}
+	inputs, err := model.GetInputs()
+	if err != nil {
+		t.Fatalf("GetInputs failed: %v", err)
+	}
+	if got := inputs[0].Shape; len(got) != 2 || got[0] != Dim(3) || got[1] != Dim(4) {
+		t.Errorf("input shape after Reshape = %v, want [3 4]", got)
+	}
+	outputs, err := model.GetOutputs()
+	if err != nil {
+		t.Fatalf("GetOutputs failed: %v", err)
+	}
+	if got := outputs[0].Shape; len(got) != 2 || got[0] != Dim(3) {
+		t.Errorf("output shape after Reshape = %v, want [3 4]", got)
+	}
+
+	if err := model.ReshapeByIndex(map[int32]PartialShape{0: {DimRange(1, 8), Dim(4)}}); err != nil {
+		t.Fatalf("ReshapeByIndex to bounded shape failed: %v", err)
+	}
+	inputs, err = model.GetInputs()
+	if err != nil {
+		t.Fatalf("GetInputs failed: %v", err)
+	}
+	if got := inputs[0].Shape; len(got) != 2 || got[0] != DimRange(1, 8) || got[1] != Dim(4) {
+		t.Errorf("input shape after bounded Reshape = %v, want [1..8,4]", got)
+	}
+
+	if err := model.Reshape(map[string]PartialShape{"input": {DynamicDim(), Dim(4)}}); err != nil {
+		t.Fatalf("Reshape to dynamic batch failed: %v", err)
+	}
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel of reshaped model failed: %v", err)
+	}
+	compiled.Close()
+}
+
+func TestModel_Reshape_invalid(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	// The [1,4] bias cannot broadcast against a last dimension of 5
+	if err := model.Reshape(map[string]PartialShape{"input": {Dim(1), Dim(5)}}); err == nil {
+		t.Error("Reshape to incompatible shape should return error")
+	}
+	if err := model.Reshape(map[string]PartialShape{"missing": {Dim(1), Dim(4)}}); err == nil {
+		t.Error("Reshape of unknown input should return error")
+	}
+	if err := model.ReshapeByIndex(map[int32]PartialShape{0: {DimRange(4, 2), Dim(4)}}); err == nil {
+		t.Error("Reshape with inverted bounds should return error")
+	}
+}
+
+func TestModel_SetLayout(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	layout, err := model.GetLayout("input")
+	if err != nil {
+		t.Fatalf("GetLayout failed: %v", err)
+	}
+	if layout != "" {
+		t.Errorf("initial layout = %q, want empty", layout)
+	}
+
+	if err := model.SetLayout("input", "nc"); err != nil {
+		t.Fatalf("SetLayout failed: %v", err)
+	}
+	layout, err = model.GetLayout("input")
+	if err != nil {
+		t.Fatalf("GetLayout failed: %v", err)
+	}
+	if layout != LayoutNC {
+		t.Errorf("layout = %q, want %q", layout, LayoutNC)
+	}
+	inputs, err := model.GetInputs()
+	if err != nil {
+		t.Fatalf("GetInputs failed: %v", err)
+	}
+	if inputs[0].Layout != LayoutNC {
+		t.Errorf("PortInfo.Layout = %q, want %q", inputs[0].Layout, LayoutNC)
+	}
+
+	if err := model.SetLayout("missing", LayoutNC); err == nil {
+		t.Error("SetLayout on an unknown port should fail")
+	}
+	if err := model.SetLayout("input", "NN"); err == nil {
+		t.Error("SetLayout with an invalid layout should fail")
+	}
+	if _, err := model.GetLayout("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetLayout on an unknown port: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestModel_SetBatch(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	if _, err := model.GetBatch(); err == nil {
+		t.Error("GetBatch without a layout should fail")
+	}
+
+	if err := model.SetLayout("input", "CN"); err != nil {
+		t.Fatalf("SetLayout failed: %v", err)
+	}
+	batch, err := model.GetBatch()
+	if err != nil {
+		t.Fatalf("GetBatch failed: %v", err)
+	}
+	if batch != Dim(4) {
+		t.Errorf("GetBatch() = %v, want 4", batch)
+	}
+
+	if err := model.SetLayout("input", LayoutNC); err != nil {
+		t.Fatalf("SetLayout failed: %v", err)
+	}
+	if err := model.SetBatch(Dim(3)); err != nil {
+		t.Fatalf("SetBatch failed: %v", err)
+	}
+	inputs, err := model.GetInputs()
+	if err != nil {
+		t.Fatalf("GetInputs failed: %v", err)
+	}
+	if got := inputs[0].Shape; len(got) != 2 || got[0] != Dim(3) || got[1] != Dim(4) {
+		t.Errorf("input shape after SetBatch = %v, want [3,4]", got)
+	}
+}
@@ -0,0 +1,745 @@
+package openvino
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
+
+// npyMagic starts every .npy file.
+const npyMagic = "\x93NUMPY"
+
+// npyTypes maps NumPy type codes, without the byte order character, to data
+// types. Types NumPy lacks use the names of the ml_dtypes package, whose
+// 4-bit types hold one element per byte.
+var npyTypes = map[string]DataType{
+	"f2":             DataTypeFloat16,
+	"f4":             DataTypeFloat32,
+	"f8":             DataTypeFloat64,
+	"i1":             DataTypeInt8,
+	"i2":             DataTypeInt16,
+	"i4":             DataTypeInt32,
+	"i8":             DataTypeInt64,
+	"u1":             DataTypeUint8,
+	"u2":             DataTypeUint16,
+	"u4":             DataTypeUint32,
+	"u8":             DataTypeUint64,
+	"b1":             DataTypeBoolean,
+	"bfloat16":       DataTypeBFloat16,
+	"float8_e4m3fn":  DataTypeFloat8E4M3,
+	"float8_e5m2":    DataTypeFloat8E5M2,
+	"float8_e8m0fnu": DataTypeFloat8E8M0,
+	"int4":           DataTypeInt4,
+	"uint4":          DataTypeUint4,
+	"float4_e2m1fn":  DataTypeFloat4E2M1,
+}
+
+// npyArray is the contents of a .npy file, with numeric data in OpenVINO's
+// layout: C order, little endian, with 4-bit types packed two to a byte.
+type npyArray struct {
+	dataType DataType
+	shape    []int64
+	data     []byte
+	strings  []string
+}
+
+// ReadNpy reads an array in NumPy .npy format into a new tensor. Numeric
+// and boolean arrays in C or Fortran order and either byte order are
+// supported, as are the ml_dtypes types bfloat16, float8 and 4-bit integers.
+// Unicode and byte string arrays become DataTypeString tensors.
+func ReadNpy(r io.Reader) (*Tensor, error) {
+	const op = "ReadNpy"
+	a, err := decodeNpy(r)
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	t, err := a.tensor(op)
+	return t, wrapError(err, nil, op)
+}
+
+// WriteNpy writes t to w in NumPy .npy format, in C order unless
+// NpyFortranOrder is given. Types NumPy lacks are written under their
+// ml_dtypes names, which NumPy reads once ml_dtypes is imported. Packed
+// types other than the 4-bit ones have no NumPy representation and fail
+// with ErrUnsupportedType.
+func WriteNpy(w io.Writer, t *Tensor, options ...NpyOption) error {
+	const op = "WriteNpy"
+	var cfg npyConfig
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	a, err := npyArrayOf(t, op)
+	if err != nil {
+		return err
+	}
+	return wrapError(encodeNpy(w, a, cfg.fortranOrder), nil, op)
+}
+
+// ReadNpz reads a NumPy .npz archive, as written by numpy.savez or
+// numpy.savez_compressed, into a tensor per array, keyed by array name.
+func ReadNpz(r io.ReaderAt, size int64) (map[string]*Tensor, error) {
+	const op = "ReadNpz"
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	tensors := make(map[string]*Tensor, len(zr.File))
+	closeAll := func() {
+		for _, t := range tensors {
+			t.Close()
+		}
+	}
+	for _, f := range zr.File {
+		name := strings.TrimSuffix(f.Name, ".npy")
+		rc, err := f.Open()
+		if err != nil {
+			closeAll()
+			return nil, wrapError(fmt.Errorf("array %q: %w", name, err), ErrInvalidTensor, op)
+		}
+		a, err := decodeNpy(rc)
+		rc.Close()
+		if err != nil {
+			closeAll()
+			return nil, wrapError(fmt.Errorf("array %q: %w", name, err), ErrInvalidTensor, op)
+		}
+		t, err := a.tensor(op)
+		if err != nil {
+			closeAll()
+			return nil, wrapError(fmt.Errorf("array %q: %w", name, err), nil, op)
+		}
+		tensors[name] = t
+	}
+	return tensors, nil
+}
+
+// WriteNpz writes tensors to w as an uncompressed NumPy .npz archive, like
+// numpy.savez, with one .npy entry per tensor in name order.
+func WriteNpz(w io.Writer, tensors map[string]*Tensor, options ...NpyOption) error {
+	const op = "WriteNpz"
+	var cfg npyConfig
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		a, err := npyArrayOf(tensors[name], op)
+		if err != nil {
+			return wrapError(fmt.Errorf("array %q: %w", name, err), nil, op)
+		}
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Store})
+		if err != nil {
+			return wrapError(fmt.Errorf("array %q: %w", name, err), nil, op)
+		}
+		if err := encodeNpy(fw, a, cfg.fortranOrder); err != nil {
+			return wrapError(fmt.Errorf("array %q: %w", name, err), nil, op)
+		}
+	}
+	return wrapError(zw.Close(), nil, op)
+}
+
+// tensor creates a tensor holding a.
+func (a *npyArray) tensor(op string) (*Tensor, error) {
+	if a.dataType == DataTypeString {
+		return NewTensorFromStrings(a.shape, a.strings)
+	}
+	t, err := NewTensor(a.dataType, a.shape)
+	if err != nil {
+		return nil, err
+	}
+	mem, err := rawView(t.tensor, op)
+	if err != nil {
+		t.Close()
+		return nil, err
+	}
+	copy(mem, a.data)
+	return t, nil
+}
+
+// npyArrayOf copies the contents of t.
+func npyArrayOf(t *Tensor, op string) (*npyArray, error) {
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	dataType, err := tensor.GetElementType()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	shape, err := tensor.GetShape()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	a := &npyArray{dataType: dataType, shape: shape}
+	if dataType == DataTypeString {
+		a.strings, err = tensor.GetStrings()
+		return a, wrapError(err, ErrInvalidTensor, op)
+	}
+	if _, err := npyDescr(dataType); err != nil {
+		return nil, wrapError(err, ErrUnsupportedType, op)
+	}
+	mem, err := rawView(tensor, op)
+	if err != nil {
+		return nil, err
+	}
+	a.data = append([]byte(nil), mem...)
+	return a, nil
+}
+
+// rawView returns the memory of tensor as bytes.
+func rawView(tensor *cgo.Tensor, op string) ([]byte, error) {
+	if err := checkContinuous(tensor, op); err != nil {
+		return nil, err
+	}
+	size, err := tensor.GetByteSize()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	if size == 0 {
+		return []byte{}, nil
+	}
+	ptr, err := tensor.DataPointer()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	return unsafe.Slice((*byte)(ptr), size), nil
+}
+
+// npyDescr returns the little-endian NumPy type descriptor of dataType.
+func npyDescr(dataType DataType) (string, error) {
+	for code, dt := range npyTypes {
+		if dt != dataType {
+			continue
+		}
+		if len(code) == 2 {
+			if dataType.Size() == 1 {
+				return "|" + code, nil
+			}
+			return "<" + code, nil
+		}
+		return code, nil
+	}
+	return "", fmt.Errorf("data type %v has no NumPy equivalent", dataType)
+}
+
+// isNibble reports whether dataType is a 4-bit type, stored one element per
+// byte in .npy files and two per byte in tensors.
+func isNibble(dataType DataType) bool {
+	return dataType.BitWidth() == 4
+}
+
+// elementCount returns the number of elements of shape. ok is false if a
+// dimension is negative or the count overflows.
+func elementCount(shape []int64) (n int64, ok bool) {
+	n = 1
+	for _, d := range shape {
+		if d < 0 {
+			return 0, false
+		}
+		if d != 0 && n > math.MaxInt64/d {
+			return 0, false
+		}
+		n *= d
+	}
+	return n, true
+}
+
+// readNpyData reads n items of itemSize bytes. The buffer grows with the
+// data actually read, so a header claiming a huge array cannot force a large
+// allocation up front.
+func readNpyData(r io.Reader, n int64, itemSize int) ([]byte, error) {
+	if itemSize > 0 && n > math.MaxInt/int64(itemSize) {
+		return nil, fmt.Errorf("invalid .npy header: %d items of %d bytes is too large", n, itemSize)
+	}
+	size := n * int64(itemSize)
+	var buf bytes.Buffer
+	read, err := buf.ReadFrom(io.LimitReader(r, size))
+	if err != nil {
+		return nil, fmt.Errorf("reading .npy data: %w", err)
+	}
+	if read < size {
+		return nil, fmt.Errorf("reading .npy data: %w", io.ErrUnexpectedEOF)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeNpy(r io.Reader) (*npyArray, error) {
+	var preamble [8]byte
+	if _, err := io.ReadFull(r, preamble[:]); err != nil {
+		return nil, fmt.Errorf("reading .npy header: %w", err)
+	}
+	if string(preamble[:6]) != npyMagic {
+		return nil, errors.New("not a .npy file")
+	}
+	var headerLen int
+	switch major := preamble[6]; major {
+	case 1:
+		var n [2]byte
+		if _, err := io.ReadFull(r, n[:]); err != nil {
+			return nil, fmt.Errorf("reading .npy header: %w", err)
+		}
+		headerLen = int(binary.LittleEndian.Uint16(n[:]))
+	case 2, 3:
+		var n [4]byte
+		if _, err := io.ReadFull(r, n[:]); err != nil {
+			return nil, fmt.Errorf("reading .npy header: %w", err)
+		}
+		headerLen = int(binary.LittleEndian.Uint32(n[:]))
+	default:
+		return nil, fmt.Errorf(".npy format version %d is not supported", major)
+	}
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("reading .npy header: %w", err)
+	}
+
+	descr, fortran, shape, err := parseNpyHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+	n, ok := elementCount(shape)
+	if !ok {
+		return nil, fmt.Errorf("invalid .npy header: shape %v is too large", shape)
+	}
+
+	a := &npyArray{shape: shape}
+	order, code := byte('|'), descr
+	if c := descr[0]; c == '<' || c == '>' || c == '|' || c == '=' {
+		order, code = c, descr[1:]
+	}
+	bigEndian := order == '>'
+
+	if code != "" && (code[0] == 'U' || code[0] == 'S') {
+		width, err := strconv.Atoi(code[1:])
+		if err != nil || width < 1 || width > math.MaxInt32/4 {
+			return nil, fmt.Errorf("invalid string type %q", descr)
+		}
+		itemSize := width
+		if code[0] == 'U' {
+			itemSize *= 4
+		}
+		raw, err := readNpyData(r, n, itemSize)
+		if err != nil {
+			return nil, err
+		}
+		a.dataType = DataTypeString
+		a.strings = make([]string, n)
+		for i := range a.strings {
+			item := raw[i*itemSize : (i+1)*itemSize]
+			if code[0] == 'U' {
+				a.strings[i] = decodeUTF32(item, bigEndian)
+			} else {
+				a.strings[i] = strings.TrimRight(string(item), "\x00")
+			}
+		}
+		if fortran {
+			a.strings = reorder(a.strings, shape, false)
+		}
+		return a, nil
+	}
+
+	dataType, ok := npyTypes[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: NumPy type %q", ErrUnsupportedType, descr)
+	}
+	a.dataType = dataType
+	itemSize := dataType.Size()
+	raw, err := readNpyData(r, n, itemSize)
+	if err != nil {
+		return nil, err
+	}
+	if bigEndian && itemSize > 1 {
+		swapBytes(raw, itemSize)
+	}
+	if fortran {
+		raw = reorderBytes(raw, shape, itemSize, false)
+	}
+	if isNibble(dataType) {
+		raw = packNibbles(raw)
+	}
+	a.data = raw
+	return a, nil
+}
+
+func encodeNpy(w io.Writer, a *npyArray, fortran bool) error {
+	var descr string
+	if a.dataType == DataTypeString {
+		width := 1
+		for _, s := range a.strings {
+			width = max(width, utf8.RuneCountInString(s))
+		}
+		descr = "<U" + strconv.Itoa(width)
+	} else {
+		var err error
+		if descr, err = npyDescr(a.dataType); err != nil {
+			return fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+		}
+	}
+	if _, err := w.Write(npyHeader(descr, fortran, a.shape)); err != nil {
+		return err
+	}
+
+	if a.dataType == DataTypeString {
+		strs := a.strings
+		if fortran {
+			strs = reorder(strs, a.shape, true)
+		}
+		width, _ := strconv.Atoi(descr[2:])
+		item := make([]byte, 4*width)
+		for _, s := range strs {
+			clear(item)
+			i := 0
+			for _, c := range s {
+				binary.LittleEndian.PutUint32(item[i:], uint32(c))
+				i += 4
+			}
+			if _, err := w.Write(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data := a.data
+	n := int64(1)
+	for _, d := range a.shape {
+		n *= d
+	}
+	if isNibble(a.dataType) {
+		data = unpackNibbles(data, n, a.dataType == DataTypeInt4)
+	}
+	if fortran {
+		data = reorderBytes(data, a.shape, a.dataType.Size(), true)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// npyHeader formats the .npy preamble and header, padding it so that the
+// data starts at a multiple of 64 bytes.
+func npyHeader(descr string, fortran bool, shape []int64) []byte {
+	var dims string
+	switch len(shape) {
+	case 0:
+	case 1:
+		dims = fmt.Sprintf("%d,", shape[0])
+	default:
+		parts := make([]string, len(shape))
+		for i, d := range shape {
+			parts[i] = strconv.FormatInt(d, 10)
+		}
+		dims = strings.Join(parts, ", ")
+	}
+	order := "False"
+	if fortran {
+		order = "True"
+	}
+	dict := fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': (%s), }", descr, order, dims)
+
+	preamble := 10
+	if len(dict)+1+preamble+63 > 0xffff {
+		preamble = 12
+	}
+	total := (preamble + len(dict) + 1 + 63) / 64 * 64
+	headerLen := total - preamble
+
+	buf := make([]byte, 0, total)
+	buf = append(buf, npyMagic...)
+	if preamble == 10 {
+		buf = append(buf, 1, 0)
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(headerLen))
+	} else {
+		buf = append(buf, 2, 0)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(headerLen))
+	}
+	buf = append(buf, dict...)
+	for len(buf) < total-1 {
+		buf = append(buf, ' ')
+	}
+	return append(buf, '\n')
+}
+
+// parseNpyHeader parses the header dictionary of a .npy file.
+func parseNpyHeader(header string) (descr string, fortran bool, shape []int64, err error) {
+	p := &pyParser{s: header}
+	v, err := p.value()
+	if err != nil {
+		return "", false, nil, fmt.Errorf("invalid .npy header: %w", err)
+	}
+	dict, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false, nil, errors.New("invalid .npy header: not a dictionary")
+	}
+	switch d := dict["descr"].(type) {
+	case string:
+		descr = d
+	case []interface{}:
+		return "", false, nil, fmt.Errorf("%w: structured NumPy arrays", ErrUnsupportedType)
+	default:
+		return "", false, nil, errors.New("invalid .npy header: missing descr")
+	}
+	if descr == "" {
+		return "", false, nil, errors.New("invalid .npy header: empty descr")
+	}
+	if fortran, ok = dict["fortran_order"].(bool); !ok {
+		return "", false, nil, errors.New("invalid .npy header: missing fortran_order")
+	}
+	dims, ok := dict["shape"].([]interface{})
+	if !ok {
+		return "", false, nil, errors.New("invalid .npy header: missing shape")
+	}
+	shape = make([]int64, len(dims))
+	for i, d := range dims {
+		n, ok := d.(int64)
+		if !ok || n < 0 {
+			return "", false, nil, fmt.Errorf("invalid .npy header: bad dimension %v", d)
+		}
+		shape[i] = n
+	}
+	return descr, fortran, shape, nil
+}
+
+// pyParser parses the Python literals that make up .npy headers:
+// dictionaries, tuples, lists, strings, integers, booleans and None.
+type pyParser struct {
+	s   string
+	pos int
+}
+
+func (p *pyParser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *pyParser) value() (interface{}, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	switch c := p.s[p.pos]; {
+	case c == '{':
+		p.pos++
+		dict := make(map[string]interface{})
+		for {
+			p.skipSpace()
+			if p.pos < len(p.s) && p.s[p.pos] == '}' {
+				p.pos++
+				return dict, nil
+			}
+			k, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("dictionary key %v is not a string", k)
+			}
+			p.skipSpace()
+			if p.pos >= len(p.s) || p.s[p.pos] != ':' {
+				return nil, fmt.Errorf("expected ':' at offset %d", p.pos)
+			}
+			p.pos++
+			if dict[key], err = p.value(); err != nil {
+				return nil, err
+			}
+			if err := p.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	case c == '(' || c == '[':
+		end := byte(')')
+		if c == '[' {
+			end = ']'
+		}
+		p.pos++
+		items := []interface{}{}
+		for {
+			p.skipSpace()
+			if p.pos < len(p.s) && p.s[p.pos] == end {
+				p.pos++
+				return items, nil
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+			if err := p.separator(end); err != nil {
+				return nil, err
+			}
+		}
+	case c == '\'' || c == '"':
+		start := p.pos + 1
+		end := strings.IndexByte(p.s[start:], c)
+		if end < 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		p.pos = start + end + 1
+		return p.s[start : start+end], nil
+	case c == '-' || c >= '0' && c <= '9':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		n, err := strconv.ParseInt(p.s[start:p.pos], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		// Python 2 wrote long integers with an L suffix.
+		if p.pos < len(p.s) && p.s[p.pos] == 'L' {
+			p.pos++
+		}
+		return n, nil
+	}
+	for _, lit := range []struct {
+		name  string
+		value interface{}
+	}{{"True", true}, {"False", false}, {"None", nil}} {
+		if strings.HasPrefix(p.s[p.pos:], lit.name) {
+			p.pos += len(lit.name)
+			return lit.value, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", p.s[p.pos], p.pos)
+}
+
+// separator consumes the comma after an item, or checks for end.
+func (p *pyParser) separator(end byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return io.ErrUnexpectedEOF
+	}
+	switch p.s[p.pos] {
+	case ',':
+		p.pos++
+		return nil
+	case end:
+		return nil
+	}
+	return fmt.Errorf("expected ',' or %q at offset %d", end, p.pos)
+}
+
+func decodeUTF32(item []byte, bigEndian bool) string {
+	var b strings.Builder
+	for i := 0; i+4 <= len(item); i += 4 {
+		var c uint32
+		if bigEndian {
+			c = binary.BigEndian.Uint32(item[i:])
+		} else {
+			c = binary.LittleEndian.Uint32(item[i:])
+		}
+		if c == 0 {
+			break
+		}
+		b.WriteRune(rune(c))
+	}
+	return b.String()
+}
+
+// swapBytes reverses the bytes of each size-byte element of data in place.
+func swapBytes(data []byte, size int) {
+	for i := 0; i+size <= len(data); i += size {
+		e := data[i : i+size]
+		for j, k := 0, size-1; j < k; j, k = j+1, k-1 {
+			e[j], e[k] = e[k], e[j]
+		}
+	}
+}
+
+// fortranIndices returns, for each element of an array of shape in C
+// order, its index in Fortran order.
+func fortranIndices(shape []int64) []int64 {
+	n := int64(1)
+	for _, d := range shape {
+		n *= d
+	}
+	indices := make([]int64, n)
+	idx := make([]int64, len(shape))
+	for c := range indices {
+		f, stride := int64(0), int64(1)
+		for j, d := range shape {
+			f += idx[j] * stride
+			stride *= d
+		}
+		indices[c] = f
+		for j := len(shape) - 1; j >= 0; j-- {
+			idx[j]++
+			if idx[j] < shape[j] {
+				break
+			}
+			idx[j] = 0
+		}
+	}
+	return indices
+}
+
+// reorder converts the elements of an array of shape from Fortran to C
+// order, or from C to Fortran order if toFortran is set.
+func reorder[T any](v []T, shape []int64, toFortran bool) []T {
+	out := make([]T, len(v))
+	for c, f := range fortranIndices(shape) {
+		if toFortran {
+			out[f] = v[c]
+		} else {
+			out[c] = v[f]
+		}
+	}
+	return out
+}
+
+// reorderBytes is reorder for elements of size bytes.
+func reorderBytes(data []byte, shape []int64, size int, toFortran bool) []byte {
+	out := make([]byte, len(data))
+	for c, f := range fortranIndices(shape) {
+		src, dst := int(f)*size, c*size
+		if toFortran {
+			src, dst = dst, src
+		}
+		copy(out[dst:dst+size], data[src:src+size])
+	}
+	return out
+}
+
+// packNibbles packs one 4-bit element per byte into two per byte, the first
+// in the low nibble.
+func packNibbles(data []byte) []byte {
+	out := make([]byte, (len(data)+1)/2)
+	for i, b := range data {
+		out[i/2] |= (b & 0x0f) << (4 * (i % 2))
+	}
+	return out
+}
+
+// unpackNibbles unpacks n 4-bit elements into one per byte, sign extending
+// them if signed.
+func unpackNibbles(data []byte, n int64, signed bool) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		b := data[i/2] >> (4 * (i % 2)) & 0x0f
+		if signed && b&0x08 != 0 {
+			b |= 0xf0
+		}
+		out[i] = b
+	}
+	return out
+}
@@ -0,0 +1,259 @@
+package openvino
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// numpyFile builds a .npy file the way numpy.save lays it out.
+func numpyFile(dict string, data []byte) []byte {
+	header := dict
+	for (10+len(header)+1)%64 != 0 {
+		header += " "
+	}
+	header += "\n"
+	var b bytes.Buffer
+	b.WriteString("\x93NUMPY\x01\x00")
+	binary.Write(&b, binary.LittleEndian, uint16(len(header)))
+	b.WriteString(header)
+	b.Write(data)
+	return b.Bytes()
+}
+
+func TestDecodeNpy(t *testing.T) {
+	// np.arange(6, dtype='>i2').reshape(2, 3) saved in Fortran order.
+	var fortran []byte
+	for _, v := range []int16{0, 3, 1, 4, 2, 5} {
+		fortran = binary.BigEndian.AppendUint16(fortran, uint16(v))
+	}
+	a, err := decodeNpy(bytes.NewReader(numpyFile("{'descr': '>i2', 'fortran_order': True, 'shape': (2, 3), }", fortran)))
+	if err != nil {
+		t.Fatalf("decodeNpy failed: %v", err)
+	}
+	if a.dataType != DataTypeInt16 || !reflect.DeepEqual(a.shape, []int64{2, 3}) {
+		t.Fatalf("decoded %v %v, want i16 [2 3]", a.dataType, a.shape)
+	}
+	for i := int16(0); i < 6; i++ {
+		if got := int16(binary.LittleEndian.Uint16(a.data[2*i:])); got != i {
+			t.Errorf("element %d = %d", i, got)
+		}
+	}
+
+	// Unicode strings are UTF-32 padded with zeros.
+	var utf32 []byte
+	for _, s := range []string{"hi", "héllo"} {
+		item := make([]byte, 20)
+		i := 0
+		for _, c := range s {
+			binary.LittleEndian.PutUint32(item[i:], uint32(c))
+			i += 4
+		}
+		utf32 = append(utf32, item...)
+	}
+	a, err = decodeNpy(bytes.NewReader(numpyFile("{'descr': '<U5', 'fortran_order': False, 'shape': (2,), }", utf32)))
+	if err != nil {
+		t.Fatalf("decodeNpy failed: %v", err)
+	}
+	if a.dataType != DataTypeString || !reflect.DeepEqual(a.strings, []string{"hi", "héllo"}) {
+		t.Errorf("decoded %v %q, want string [hi héllo]", a.dataType, a.strings)
+	}
+
+	a, err = decodeNpy(bytes.NewReader(numpyFile("{'descr': '|S3', 'fortran_order': False, 'shape': (2L,), }", []byte("ab\x00xyz"))))
+	if err != nil {
+		t.Fatalf("decodeNpy failed: %v", err)
+	}
+	if !reflect.DeepEqual(a.strings, []string{"ab", "xyz"}) {
+		t.Errorf("decoded %q, want [ab xyz]", a.strings)
+	}
+}
+
+func TestDecodeNpy_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file []byte
+		kind error
+	}{
+		{"magic", []byte("PK\x03\x04 not npy"), nil},
+		{"structured", numpyFile("{'descr': [('a', '<i4')], 'fortran_order': False, 'shape': (1,), }", make([]byte, 4)), ErrUnsupportedType},
+		{"object", numpyFile("{'descr': '|O', 'fortran_order': False, 'shape': (1,), }", make([]byte, 8)), ErrUnsupportedType},
+		{"truncated", numpyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (4,), }", make([]byte, 8)), nil},
+		{"shape", numpyFile("{'descr': '<f4', 'fortran_order': False, }", nil), nil},
+		{"overflowing shape", numpyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (1099511627776, 1099511627776), }", nil), nil},
+		{"overflowing size", numpyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (2305843009213693952,), }", nil), nil},
+		{"huge shape", numpyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (1073741824, 1024), }", make([]byte, 16)), nil},
+		{"huge string width", numpyFile("{'descr': '<U4294967296', 'fortran_order': False, 'shape': (1,), }", make([]byte, 16)), nil},
+		{"empty string width", numpyFile("{'descr': '<U0', 'fortran_order': False, 'shape': (1099511627776,), }", nil), nil},
+	}
+	for _, tt := range tests {
+		_, err := decodeNpy(bytes.NewReader(tt.file))
+		if err == nil {
+			t.Errorf("%s: decodeNpy succeeded, want an error", tt.name)
+		} else if tt.kind != nil && !errors.Is(err, tt.kind) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.kind)
+		}
+	}
+}
+
+func TestEncodeNpy_roundTrip(t *testing.T) {
+	f32 := make([]byte, 0, 24)
+	for i := 0; i < 6; i++ {
+		f32 = binary.LittleEndian.AppendUint32(f32, uint32(i))
+	}
+	tests := []struct {
+		name  string
+		array npyArray
+	}{
+		{"f32", npyArray{dataType: DataTypeFloat32, shape: []int64{2, 3}, data: f32}},
+		{"scalar", npyArray{dataType: DataTypeUint8, shape: []int64{}, data: []byte{7}}},
+		{"bool", npyArray{dataType: DataTypeBoolean, shape: []int64{3}, data: []byte{1, 0, 1}}},
+		{"i4", npyArray{dataType: DataTypeInt4, shape: []int64{3}, data: []byte{0x9f, 0x07}}},
+		{"bf16", npyArray{dataType: DataTypeBFloat16, shape: []int64{1}, data: []byte{0x80, 0x3f}}},
+		{"strings", npyArray{dataType: DataTypeString, shape: []int64{2, 2}, strings: []string{"a", "bb", "", "日本"}}},
+	}
+	for _, tt := range tests {
+		for _, fortran := range []bool{false, true} {
+			var buf bytes.Buffer
+			if err := encodeNpy(&buf, &tt.array, fortran); err != nil {
+				t.Fatalf("%s: encodeNpy failed: %v", tt.name, err)
+			}
+			if i := bytes.IndexByte(buf.Bytes(), '\n'); (i+1)%64 != 0 {
+				t.Errorf("%s: data starts at offset %d, want a multiple of 64", tt.name, i+1)
+			}
+			got, err := decodeNpy(&buf)
+			if err != nil {
+				t.Fatalf("%s: decodeNpy failed: %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(*got, tt.array) {
+				t.Errorf("%s (fortran %v): round trip gave %+v, want %+v", tt.name, fortran, *got, tt.array)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := encodeNpy(&buf, &npyArray{dataType: DataTypeNF4, shape: []int64{2}, data: []byte{0}}, false); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("encodeNpy(nf4): got %v, want ErrUnsupportedType", err)
+	}
+}
+
+func TestEncodeNpy_fortranLayout(t *testing.T) {
+	a := npyArray{dataType: DataTypeUint8, shape: []int64{2, 3}, data: []byte{0, 1, 2, 3, 4, 5}}
+	var buf bytes.Buffer
+	if err := encodeNpy(&buf, &a, true); err != nil {
+		t.Fatalf("encodeNpy failed: %v", err)
+	}
+	b := buf.Bytes()
+	header := string(b[10:bytes.IndexByte(b, '\n')])
+	if !strings.HasPrefix(header, "{'descr': '|u1', 'fortran_order': True, 'shape': (2, 3), }") {
+		t.Errorf("header = %q", header)
+	}
+	if got := b[len(b)-6:]; !bytes.Equal(got, []byte{0, 3, 1, 4, 2, 5}) {
+		t.Errorf("Fortran-order data = %v, want [0 3 1 4 2 5]", got)
+	}
+}
+
+func TestReadNpy(t *testing.T) {
+	coreAvailable(t).Close()
+
+	data := []float32{1.5, -2, 3, 4}
+	src, err := NewTensorFromSlice([]int64{2, 2}, data)
+	if err != nil {
+		t.Fatalf("NewTensorFromSlice failed: %v", err)
+	}
+	defer src.Close()
+
+	var buf bytes.Buffer
+	if err := WriteNpy(&buf, src, NpyFortranOrder(true)); err != nil {
+		t.Fatalf("WriteNpy failed: %v", err)
+	}
+	got, err := ReadNpy(&buf)
+	if err != nil {
+		t.Fatalf("ReadNpy failed: %v", err)
+	}
+	defer got.Close()
+	values, err := TensorData[float32](got)
+	if err != nil {
+		t.Fatalf("TensorData failed: %v", err)
+	}
+	if !reflect.DeepEqual(values, data) {
+		t.Errorf("read %v, want %v", values, data)
+	}
+
+	if _, err := ReadNpy(strings.NewReader("not npy")); !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("ReadNpy of garbage: got %v, want ErrInvalidTensor", err)
+	}
+}
+
+func TestReadNpy_scalar(t *testing.T) {
+	coreAvailable(t).Close()
+
+	// np.save(f, np.float32(1.5))
+	file := numpyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (), }", binary.LittleEndian.AppendUint32(nil, 0x3fc00000))
+	got, err := ReadNpy(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("ReadNpy failed: %v", err)
+	}
+	defer got.Close()
+	if shape, err := got.GetShape(); err != nil || len(shape) != 0 {
+		t.Errorf("shape = %v, %v, want []", shape, err)
+	}
+	values, err := TensorData[float32](got)
+	if err != nil || len(values) != 1 || values[0] != 1.5 {
+		t.Errorf("TensorData = %v, %v, want [1.5]", values, err)
+	}
+}
+
+func TestNpz(t *testing.T) {
+	coreAvailable(t).Close()
+
+	ids, err := NewTensorWithData(DataTypeInt64, []int64{3}, []int64{7, 8, 9})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer ids.Close()
+	words, err := NewTensorFromStrings([]int64{2}, []string{"open", "vino"})
+	if err != nil {
+		t.Fatalf("NewTensorFromStrings failed: %v", err)
+	}
+	defer words.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture.npz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteNpz(f, map[string]*Tensor{"ids": ids, "words": words}); err != nil {
+		t.Fatalf("WriteNpz failed: %v", err)
+	}
+	f.Close()
+
+	f, err = os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	info, _ := f.Stat()
+	tensors, err := ReadNpz(f, info.Size())
+	if err != nil {
+		t.Fatalf("ReadNpz failed: %v", err)
+	}
+	defer func() {
+		for _, t := range tensors {
+			t.Close()
+		}
+	}()
+	if len(tensors) != 2 {
+		t.Fatalf("ReadNpz returned %d arrays, want 2", len(tensors))
+	}
+	if got, _ := tensors["ids"].GetDataAsInt64(); !reflect.DeepEqual(got, []int64{7, 8, 9}) {
+		t.Errorf("ids = %v, want [7 8 9]", got)
+	}
+	if got, _ := tensors["words"].GetDataAsString(); !reflect.DeepEqual(got, []string{"open", "vino"}) {
+		t.Errorf("words = %v, want [open vino]", got)
+	}
+}
@@ -1,6 +1,9 @@
 package openvino
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type CompileOption func(map[string]string)
 
@@ -21,3 +24,127 @@ func InferenceNumThreads(n int) CompileOption {
 		props["INFERENCE_NUM_THREADS"] = fmt.Sprintf("%d", n)
 	}
 }
+
+// CacheDir enables the model cache in dir. Compiled blobs are written there on
+// the first compile and imported on later ones. It can be passed to
+// Core.CompileModel, or to Core.SetProperty with an empty device to enable
+// caching for every compile.
+func CacheDir(dir string) CompileOption {
+	return func(props map[string]string) {
+		props["CACHE_DIR"] = dir
+	}
+}
+
+// EnableProfiling turns on per-node performance counters (PERF_COUNT) so that
+// InferRequest.GetProfilingInfo reports timings.
+func EnableProfiling() CompileOption {
+	return func(props map[string]string) {
+		props["PERF_COUNT"] = "YES"
+	}
+}
+
+// Property sets an arbitrary OpenVINO property by name, for keys that have no
+// dedicated option.
+func Property(name, value string) CompileOption {
+	return func(props map[string]string) {
+		props[name] = value
+	}
+}
+
+// SaveOption configures Model.Save.
+type SaveOption func(*saveConfig)
+
+type saveConfig struct {
+	compressToFP16 bool
+}
+
+// CompressToFP16 stores floating point weights as FP16 in the saved .bin,
+// roughly halving its size. Weights are decompressed when the model is
+// compiled, so inference precision is governed by the device as usual.
+func CompressToFP16(enable bool) SaveOption {
+	return func(cfg *saveConfig) {
+		cfg.compressToFP16 = enable
+	}
+}
+
+// NpyOption configures WriteNpy and WriteNpz.
+type NpyOption func(*npyConfig)
+
+type npyConfig struct {
+	fortranOrder bool
+}
+
+// NpyFortranOrder writes arrays in Fortran (column-major) order instead of
+// C order.
+func NpyFortranOrder(enable bool) NpyOption {
+	return func(cfg *npyConfig) {
+		cfg.fortranOrder = enable
+	}
+}
+
+// QueueOption configures CompiledModel.NewAsyncInferQueue.
+type QueueOption func(*queueConfig)
+
+type queueConfig struct {
+	size int
+}
+
+// QueueSize sets the number of infer requests in the queue. By default, and
+// when n is not positive, the queue uses the compiled model's
+// OPTIMAL_NUMBER_OF_INFER_REQUESTS.
+func QueueSize(n int) QueueOption {
+	return func(cfg *queueConfig) {
+		cfg.size = n
+	}
+}
+
+// BatcherOption configures CompiledModel.NewBatcher.
+type BatcherOption func(*batcherConfig)
+
+type batcherConfig struct {
+	maxBatch int
+	maxDelay time.Duration
+	requests int
+	pads     map[string]batchPad
+}
+
+type batchPad struct {
+	axis  int
+	value float64
+}
+
+// MaxBatch sets the largest number of items run in one inference. It
+// defaults to the batch size of a static-batch model, and to 16 otherwise.
+func MaxBatch(n int) BatcherOption {
+	return func(cfg *batcherConfig) {
+		cfg.maxBatch = n
+	}
+}
+
+// MaxDelay sets how long the first item of a batch waits for more items
+// before the batch runs anyway. It defaults to one millisecond.
+func MaxDelay(d time.Duration) BatcherOption {
+	return func(cfg *batcherConfig) {
+		cfg.maxDelay = d
+	}
+}
+
+// BatchRequests sets how many batches may run at once. By default the
+// compiled model's OPTIMAL_NUMBER_OF_INFER_REQUESTS is used.
+func BatchRequests(n int) BatcherOption {
+	return func(cfg *batcherConfig) {
+		cfg.requests = n
+	}
+}
+
+// PadAxis lets items of the named input differ in length along axis, such
+// as a sequence dimension. Shorter items are padded with value up to the
+// longest item of the batch, or to the length of a static dimension.
+func PadAxis(input string, axis int, value float64) BatcherOption {
+	return func(cfg *batcherConfig) {
+		if cfg.pads == nil {
+			cfg.pads = make(map[string]batchPad)
+		}
+		cfg.pads[input] = batchPad{axis: axis, value: value}
+	}
+}
@@ -58,3 +58,39 @@ func TestPerformanceMode_constants(t *testing.T) {
 		t.Errorf("PerformanceModeThroughput = %q, want THROUGHPUT", PerformanceModeThroughput)
 	}
 }
+
+func TestProperty(t *testing.T) {
+	props := make(map[string]string)
+	Property("ENABLE_MMAP", "NO")(props)
+	if props["ENABLE_MMAP"] != "NO" {
+		t.Errorf("ENABLE_MMAP = %s, want NO", props["ENABLE_MMAP"])
+	}
+}
+
+func TestCacheDir(t *testing.T) {
+	props := make(map[string]string)
+	CacheDir("/var/cache/openvino")(props)
+	if props["CACHE_DIR"] != "/var/cache/openvino" {
+		t.Errorf("CACHE_DIR = %s, want /var/cache/openvino", props["CACHE_DIR"])
+	}
+}
+
+func TestCompressToFP16(t *testing.T) {
+	var cfg saveConfig
+	CompressToFP16(true)(&cfg)
+	if !cfg.compressToFP16 {
+		t.Error("CompressToFP16(true) should enable compression")
+	}
+	CompressToFP16(false)(&cfg)
+	if cfg.compressToFP16 {
+		t.Error("CompressToFP16(false) should disable compression")
+	}
+}
+
+func TestEnableProfiling(t *testing.T) {
+	props := make(map[string]string)
+	EnableProfiling()(props)
+	if props["PERF_COUNT"] != "YES" {
+		t.Errorf("expected YES, got %s", props["PERF_COUNT"])
+	}
+}
@@ -41,7 +41,7 @@ func TestInferRequest_SetOutputTensor(t *testing.T) {
 	}
 	size := int64(1)
 	for _, d := range shape {
-		size *= int64(d)
+		size *= d.Length()
 	}
 	data := make([]float32, size)
 	err = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -117,7 +117,7 @@ func TestInferRequest_SetOutputTensorByIndex(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
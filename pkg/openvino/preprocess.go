@@ -0,0 +1,353 @@
+package openvino
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
+
+// ColorFormat is the color encoding of an image input tensor.
+type ColorFormat int32
+
+const (
+	ColorFormatNV12SinglePlane ColorFormat = iota
+	ColorFormatNV12TwoPlanes
+	ColorFormatI420SinglePlane
+	ColorFormatI420ThreePlanes
+	ColorFormatRGB
+	ColorFormatBGR
+	ColorFormatGray
+	ColorFormatRGBX
+	ColorFormatBGRX
+)
+
+// ResizeAlgorithm selects the interpolation used by PreProcessSteps.Resize.
+type ResizeAlgorithm int32
+
+const (
+	ResizeLinear ResizeAlgorithm = iota
+	ResizeCubic
+	ResizeNearest
+	ResizeBilinearPillow
+	ResizeBicubicPillow
+)
+
+// PrePostProcessor builds a model with input preprocessing and output
+// postprocessing embedded in its graph, so conversions such as NHWC u8 to
+// NCHW f32 run inside OpenVINO. It works on a copy of the source model.
+//
+// Builder methods do not return errors; the first failure is reported by
+// Build and later steps are skipped.
+//
+//	ppp := openvino.NewPrePostProcessor(model)
+//	defer ppp.Close()
+//	in := ppp.Input("image")
+//	in.Tensor().SetElementType(openvino.DataTypeUint8).SetLayout("NHWC")
+//	in.Preprocess().ConvertElementType(openvino.DataTypeFloat32).Mean(123.7, 116.3, 103.5).Scale(58.4, 57.1, 57.4)
+//	in.Model().SetLayout("NCHW")
+//	prepared, err := ppp.Build()
+type PrePostProcessor struct {
+	ppp *cgo.PrePostProcessor
+	err error
+}
+
+// NewPrePostProcessor starts a preprocessing pipeline for model. The model
+// itself is not modified.
+func NewPrePostProcessor(model *Model) *PrePostProcessor {
+	const op = "NewPrePostProcessor"
+	m, err := model.handle(op)
+	if err != nil {
+		return &PrePostProcessor{err: err}
+	}
+	defer runtime.KeepAlive(model)
+	ppp, err := cgo.NewPrePostProcessor(m)
+	if err != nil {
+		return &PrePostProcessor{err: wrapError(err, nil, op)}
+	}
+	return trackHandle(&PrePostProcessor{ppp: ppp}, "PrePostProcessor", (*PrePostProcessor).Close)
+}
+
+// Close releases the builder. Models returned by Build stay valid. It is
+// safe to call Close more than once.
+func (p *PrePostProcessor) Close() {
+	if p.ppp != nil {
+		runtime.SetFinalizer(p, nil)
+		p.ppp.Destroy()
+		p.ppp = nil
+	}
+}
+
+// Build returns a new model with the configured steps applied, or the first
+// error recorded by a builder step.
+func (p *PrePostProcessor) Build() (*Model, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	const op = "PrePostProcessor.Build"
+	if p.ppp == nil {
+		return nil, closedError(op, "PrePostProcessor")
+	}
+	defer runtime.KeepAlive(p)
+	model, err := p.ppp.Build()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return newModel(model), nil
+}
+
+// Input selects the input with the given tensor name. An empty name selects
+// the model's only input.
+func (p *PrePostProcessor) Input(name string) *InputInfo {
+	return &InputInfo{port{p: p, name: name, index: -1}}
+}
+
+// InputByIndex selects the input at index.
+func (p *PrePostProcessor) InputByIndex(index int) *InputInfo {
+	return &InputInfo{port{p: p, index: int32(index)}}
+}
+
+// Output selects the output with the given tensor name. An empty name
+// selects the model's only output.
+func (p *PrePostProcessor) Output(name string) *OutputInfo {
+	return &OutputInfo{port{p: p, name: name, index: -1}}
+}
+
+// OutputByIndex selects the output at index.
+func (p *PrePostProcessor) OutputByIndex(index int) *OutputInfo {
+	return &OutputInfo{port{p: p, index: int32(index)}}
+}
+
+// port identifies the input or output a builder step applies to.
+type port struct {
+	p     *PrePostProcessor
+	name  string
+	index int32
+}
+
+// apply runs step unless an earlier step failed, recording its error.
+func (pt port) apply(step func(ppp *cgo.PrePostProcessor, name string, index int32) error) {
+	if pt.p.err != nil {
+		return
+	}
+	if pt.p.ppp == nil {
+		pt.p.err = closedError("PrePostProcessor", "PrePostProcessor")
+		return
+	}
+	pt.p.err = wrapPortError(step(pt.p.ppp, pt.name, pt.index), nil, "PrePostProcessor", pt.portName())
+	runtime.KeepAlive(pt.p)
+}
+
+// portName returns the port for error messages.
+func (pt port) portName() string {
+	if pt.name == "" && pt.index >= 0 {
+		return indexPort(pt.index)
+	}
+	return pt.name
+}
+
+// InputInfo configures one model input.
+type InputInfo struct{ port }
+
+// Tensor describes the tensor the caller will supply.
+func (in *InputInfo) Tensor() *InputTensorInfo { return &InputTensorInfo{in.port} }
+
+// Preprocess returns the steps converting the supplied tensor to the model input.
+func (in *InputInfo) Preprocess() *PreProcessSteps { return &PreProcessSteps{in.port} }
+
+// Model describes the input as the original model expects it.
+func (in *InputInfo) Model() *InputModelInfo { return &InputModelInfo{in.port} }
+
+// InputTensorInfo describes the tensor supplied for an input.
+type InputTensorInfo struct{ port }
+
+// SetElementType sets the element type of the supplied tensor.
+func (t *InputTensorInfo) SetElementType(dataType DataType) *InputTensorInfo {
+	t.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputTensorSetElementType(name, index, cgo.DataType(dataType))
+	})
+	return t
+}
+
+// SetLayout sets the layout of the supplied tensor, such as "NHWC".
+func (t *InputTensorInfo) SetLayout(layout Layout) *InputTensorInfo {
+	t.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputTensorSetLayout(name, index, string(layout))
+	})
+	return t
+}
+
+// SetColorFormat sets the color encoding of the supplied image tensor.
+func (t *InputTensorInfo) SetColorFormat(format ColorFormat) *InputTensorInfo {
+	t.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputTensorSetColorFormat(name, index, int32(format))
+	})
+	return t
+}
+
+// SetSpatialStaticShape fixes the height and width of the supplied tensor.
+func (t *InputTensorInfo) SetSpatialStaticShape(height, width int64) *InputTensorInfo {
+	t.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputTensorSetSpatialShape(name, index, height, width)
+	})
+	return t
+}
+
+// SetSpatialDynamicShape lets the supplied tensor have any height and width,
+// typically combined with Resize.
+func (t *InputTensorInfo) SetSpatialDynamicShape() *InputTensorInfo {
+	t.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputTensorSetSpatialShape(name, index, -1, -1)
+	})
+	return t
+}
+
+// PreProcessSteps are applied in order to the supplied tensor.
+type PreProcessSteps struct{ port }
+
+// ConvertElementType converts the tensor to dataType.
+func (s *PreProcessSteps) ConvertElementType(dataType DataType) *PreProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputConvertElementType(name, index, cgo.DataType(dataType))
+	})
+	return s
+}
+
+// ConvertColor converts the tensor from its color format to format.
+func (s *PreProcessSteps) ConvertColor(format ColorFormat) *PreProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputConvertColor(name, index, int32(format))
+	})
+	return s
+}
+
+// Resize resizes the tensor to the model's height and width. Both layouts
+// must be known.
+func (s *PreProcessSteps) Resize(algorithm ResizeAlgorithm) *PreProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputResize(name, index, int32(algorithm), -1, -1)
+	})
+	return s
+}
+
+// ResizeTo resizes the tensor to height and width.
+func (s *PreProcessSteps) ResizeTo(algorithm ResizeAlgorithm, height, width int64) *PreProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputResize(name, index, int32(algorithm), height, width)
+	})
+	return s
+}
+
+// Mean subtracts values from the tensor: a single value from every element,
+// or one value per channel.
+func (s *PreProcessSteps) Mean(values ...float32) *PreProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		if len(values) == 0 {
+			return errors.New("Mean requires at least one value")
+		}
+		return ppp.InputMean(name, index, values)
+	})
+	return s
+}
+
+// Scale divides the tensor by values: a single value for every element, or
+// one value per channel.
+func (s *PreProcessSteps) Scale(values ...float32) *PreProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		if len(values) == 0 {
+			return errors.New("Scale requires at least one value")
+		}
+		return ppp.InputScale(name, index, values)
+	})
+	return s
+}
+
+// ConvertLayout transposes the tensor to layout. An empty layout converts to
+// the model layout.
+func (s *PreProcessSteps) ConvertLayout(layout Layout) *PreProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputConvertLayout(name, index, string(layout))
+	})
+	return s
+}
+
+// ReverseChannels reverses the channel order, e.g. RGB to BGR.
+func (s *PreProcessSteps) ReverseChannels() *PreProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputReverseChannels(name, index)
+	})
+	return s
+}
+
+// InputModelInfo describes an input as the original model expects it.
+type InputModelInfo struct{ port }
+
+// SetLayout sets the layout of the model input, such as "NCHW".
+func (m *InputModelInfo) SetLayout(layout Layout) *InputModelInfo {
+	m.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.InputModelSetLayout(name, index, string(layout))
+	})
+	return m
+}
+
+// OutputInfo configures one model output.
+type OutputInfo struct{ port }
+
+// Tensor describes the tensor the caller will receive.
+func (out *OutputInfo) Tensor() *OutputTensorInfo { return &OutputTensorInfo{out.port} }
+
+// Postprocess returns the steps converting the model output to the returned tensor.
+func (out *OutputInfo) Postprocess() *PostProcessSteps { return &PostProcessSteps{out.port} }
+
+// Model describes the output as the original model produces it.
+func (out *OutputInfo) Model() *OutputModelInfo { return &OutputModelInfo{out.port} }
+
+// OutputTensorInfo describes the tensor returned for an output.
+type OutputTensorInfo struct{ port }
+
+// SetElementType sets the element type of the returned tensor.
+func (t *OutputTensorInfo) SetElementType(dataType DataType) *OutputTensorInfo {
+	t.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.OutputTensorSetElementType(name, index, cgo.DataType(dataType))
+	})
+	return t
+}
+
+// SetLayout sets the layout of the returned tensor.
+func (t *OutputTensorInfo) SetLayout(layout Layout) *OutputTensorInfo {
+	t.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.OutputTensorSetLayout(name, index, string(layout))
+	})
+	return t
+}
+
+// PostProcessSteps are applied in order to the model output.
+type PostProcessSteps struct{ port }
+
+// ConvertElementType converts the output to dataType.
+func (s *PostProcessSteps) ConvertElementType(dataType DataType) *PostProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.OutputConvertElementType(name, index, cgo.DataType(dataType))
+	})
+	return s
+}
+
+// ConvertLayout transposes the output to layout. An empty layout converts to
+// the layout set with OutputTensorInfo.SetLayout.
+func (s *PostProcessSteps) ConvertLayout(layout Layout) *PostProcessSteps {
+	s.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.OutputConvertLayout(name, index, string(layout))
+	})
+	return s
+}
+
+// OutputModelInfo describes an output as the original model produces it.
+type OutputModelInfo struct{ port }
+
+// SetLayout sets the layout of the model output.
+func (m *OutputModelInfo) SetLayout(layout Layout) *OutputModelInfo {
+	m.apply(func(ppp *cgo.PrePostProcessor, name string, index int32) error {
+		return ppp.OutputModelSetLayout(name, index, string(layout))
+	})
+	return m
+}
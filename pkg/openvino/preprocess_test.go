@@ -0,0 +1,122 @@
+package openvino
+
+import "testing"
+
+func TestPrePostProcessor_Build(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	ppp := NewPrePostProcessor(model)
+	defer ppp.Close()
+	in := ppp.Input("input")
+	in.Tensor().SetElementType(DataTypeUint8)
+	in.Preprocess().ConvertElementType(DataTypeFloat32).Mean(1).Scale(2)
+	prepared, err := ppp.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	defer prepared.Close()
+
+	inputs, err := prepared.GetInputs()
+	if err != nil {
+		t.Fatalf("GetInputs failed: %v", err)
+	}
+	if len(inputs) != 1 || inputs[0].DataType != DataTypeUint8 {
+		t.Fatalf("prepared inputs = %+v, want a single u8 input", inputs)
+	}
+	inputs, err = model.GetInputs()
+	if err != nil {
+		t.Fatalf("GetInputs failed: %v", err)
+	}
+	if inputs[0].DataType != DataTypeFloat32 {
+		t.Errorf("source model input type = %v, want f32", inputs[0].DataType)
+	}
+
+	compiled, err := core.CompileModel(prepared, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	if err := req.SetInputTensor("input", []uint8{1, 3, 5, 7}, []int64{1, 4}, DataTypeUint8); err != nil {
+		t.Fatalf("SetInputTensor failed: %v", err)
+	}
+	if err := req.Infer(); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	out, err := req.GetOutputTensor("output")
+	if err != nil {
+		t.Fatalf("GetOutputTensor failed: %v", err)
+	}
+	defer out.Close()
+	got, err := out.GetDataAsFloat32()
+	if err != nil {
+		t.Fatalf("GetDataAsFloat32 failed: %v", err)
+	}
+	// (x - 1) / 2 + bias
+	want := []float32{1, 3, 5, 7}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("output[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPrePostProcessor_output(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	ppp := NewPrePostProcessor(model)
+	defer ppp.Close()
+	ppp.Output("").Tensor().SetElementType(DataTypeFloat16)
+	prepared, err := ppp.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	defer prepared.Close()
+
+	outputs, err := prepared.GetOutputs()
+	if err != nil {
+		t.Fatalf("GetOutputs failed: %v", err)
+	}
+	if len(outputs) != 1 || outputs[0].DataType != DataTypeFloat16 {
+		t.Errorf("prepared outputs = %+v, want a single f16 output", outputs)
+	}
+}
+
+func TestPrePostProcessor_errors(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	ppp := NewPrePostProcessor(model)
+	ppp.Input("missing").Tensor().SetElementType(DataTypeUint8)
+	if _, err := ppp.Build(); err == nil {
+		t.Error("Build with an unknown input should fail")
+	}
+	ppp.Close()
+
+	ppp = NewPrePostProcessor(model)
+	ppp.InputByIndex(0).Preprocess().Mean()
+	if _, err := ppp.Build(); err == nil {
+		t.Error("Build after Mean with no values should fail")
+	}
+	ppp.Close()
+
+	if _, err := ppp.Build(); err == nil {
+		t.Error("Build after Close should fail")
+	}
+}
@@ -0,0 +1,374 @@
+package openvino
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// ProfileStats summarizes the executed time of one node, node type or
+// execution type across the inferences added to a ProfileReport.
+type ProfileStats struct {
+	Name     string // Node name, node type or execution type
+	NodeType string // Set for per-node stats
+	ExecType string // Set for per-node stats
+	Count    int    // Number of samples
+	Total    time.Duration
+	CPUTotal time.Duration
+	Mean     time.Duration
+	Min      time.Duration
+	Max      time.Duration
+	P50      time.Duration
+	P90      time.Duration
+	P99      time.Duration
+	// PercentWall is Total as a percentage of the report's wall time.
+	PercentWall float64
+}
+
+// ProfileReport aggregates GetProfilingInfo results over many inferences.
+// Profiling must be enabled with EnableProfiling when compiling the model.
+// It is safe for concurrent use.
+//
+// Count, Total, Mean, Min and Max are exact. To bound memory over long runs,
+// the percentiles come from a uniform random sample of at most 10000
+// samples per node or type, so they are exact only up to that many
+// inferences.
+type ProfileReport struct {
+	mu         sync.Mutex
+	rand       *rand.Rand
+	inferences int
+	wall       time.Duration
+	nodes      map[string]*profileSamples
+	nodeOrder  []string
+	nodeTypes  map[string]*profileSamples
+	execTypes  map[string]*profileSamples
+}
+
+// profileMaxSamples is the most real times kept per node or type for
+// percentiles.
+const profileMaxSamples = 10000
+
+type profileSamples struct {
+	nodeType string
+	execType string
+	count    int
+	total    time.Duration
+	min      time.Duration
+	max      time.Duration
+	real     []time.Duration // Reservoir sample of the real times
+	cpu      time.Duration
+}
+
+// add records one sample, replacing a random reservoir entry once the
+// reservoir is full so that it stays a uniform sample.
+func (s *profileSamples) add(real, cpu time.Duration, rng *rand.Rand) {
+	s.count++
+	s.total += real
+	s.cpu += cpu
+	if s.count == 1 || real < s.min {
+		s.min = real
+	}
+	if s.count == 1 || real > s.max {
+		s.max = real
+	}
+	if len(s.real) < profileMaxSamples {
+		s.real = append(s.real, real)
+	} else if i := rng.Intn(s.count); i < profileMaxSamples {
+		s.real[i] = real
+	}
+}
+
+// NewProfileReport returns an empty report.
+func NewProfileReport() *ProfileReport {
+	return &ProfileReport{
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		nodes:     make(map[string]*profileSamples),
+		nodeTypes: make(map[string]*profileSamples),
+		execTypes: make(map[string]*profileSamples),
+	}
+}
+
+// Add merges the profiling info of one inference. wall is the wall-clock
+// duration of that inference; if it is zero, the summed real time of its
+// executed nodes is used instead.
+func (r *ProfileReport) Add(infos []ProfilingInfo, wall time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typeReal := make(map[string]time.Duration)
+	typeCPU := make(map[string]time.Duration)
+	execReal := make(map[string]time.Duration)
+	execCPU := make(map[string]time.Duration)
+	var executed time.Duration
+	for _, info := range infos {
+		if info.Status != ProfilingInfoStatusExecuted {
+			continue
+		}
+		real := time.Duration(info.RealTime) * time.Microsecond
+		cpu := time.Duration(info.CPUTime) * time.Microsecond
+		executed += real
+
+		s, ok := r.nodes[info.NodeName]
+		if !ok {
+			s = &profileSamples{nodeType: info.NodeType, execType: info.ExecType}
+			r.nodes[info.NodeName] = s
+			r.nodeOrder = append(r.nodeOrder, info.NodeName)
+		}
+		s.add(real, cpu, r.rand)
+
+		typeReal[info.NodeType] += real
+		typeCPU[info.NodeType] += cpu
+		execReal[info.ExecType] += real
+		execCPU[info.ExecType] += cpu
+	}
+	r.addGrouped(r.nodeTypes, typeReal, typeCPU)
+	r.addGrouped(r.execTypes, execReal, execCPU)
+
+	if wall <= 0 {
+		wall = executed
+	}
+	r.wall += wall
+	r.inferences++
+}
+
+// addGrouped records one sample per group for the current inference.
+func (r *ProfileReport) addGrouped(dst map[string]*profileSamples, real, cpu map[string]time.Duration) {
+	for name, d := range real {
+		s, ok := dst[name]
+		if !ok {
+			s = &profileSamples{}
+			dst[name] = s
+		}
+		s.add(d, cpu[name], r.rand)
+	}
+}
+
+// Inferences returns the number of inferences added.
+func (r *ProfileReport) Inferences() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.inferences
+}
+
+// WallTime returns the total wall time of the inferences added.
+func (r *ProfileReport) WallTime() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.wall
+}
+
+// Nodes returns per-node stats, slowest first.
+func (r *ProfileReport) Nodes() []ProfileStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make([]ProfileStats, 0, len(r.nodes))
+	for _, name := range r.nodeOrder {
+		s := r.nodes[name]
+		st := r.summarize(name, s)
+		st.NodeType = s.nodeType
+		st.ExecType = s.execType
+		stats = append(stats, st)
+	}
+	sortStats(stats)
+	return stats
+}
+
+// ByNodeType returns stats per node type, such as Convolution, slowest first.
+// Each inference contributes one sample per type: the sum over its nodes.
+func (r *ProfileReport) ByNodeType() []ProfileStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.grouped(r.nodeTypes)
+}
+
+// ByExecType returns stats per execution type, such as jit_avx2_FP32,
+// slowest first. Each inference contributes one sample per type.
+func (r *ProfileReport) ByExecType() []ProfileStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.grouped(r.execTypes)
+}
+
+func (r *ProfileReport) grouped(groups map[string]*profileSamples) []ProfileStats {
+	stats := make([]ProfileStats, 0, len(groups))
+	for name, s := range groups {
+		stats = append(stats, r.summarize(name, s))
+	}
+	sortStats(stats)
+	return stats
+}
+
+func (r *ProfileReport) summarize(name string, s *profileSamples) ProfileStats {
+	sorted := append([]time.Duration(nil), s.real...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	st := ProfileStats{Name: name, Count: s.count, Total: s.total, CPUTotal: s.cpu, Min: s.min, Max: s.max}
+	if len(sorted) == 0 {
+		return st
+	}
+	st.Mean = st.Total / time.Duration(s.count)
+	st.P50 = percentile(sorted, 50)
+	st.P90 = percentile(sorted, 90)
+	st.P99 = percentile(sorted, 99)
+	if r.wall > 0 {
+		st.PercentWall = 100 * float64(st.Total) / float64(r.wall)
+	}
+	return st
+}
+
+// percentile returns the nearest-rank percentile p of sorted samples.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func sortStats(stats []ProfileStats) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Total != stats[j].Total {
+			return stats[i].Total > stats[j].Total
+		}
+		return stats[i].Name < stats[j].Name
+	})
+}
+
+// WriteText writes the report as aligned text tables.
+func (r *ProfileReport) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Inferences: %d\tWall time: %v\n", r.Inferences(), r.WallTime())
+
+	sections := []struct {
+		title string
+		stats []ProfileStats
+		node  bool
+	}{
+		{"Nodes", r.Nodes(), true},
+		{"By node type", r.ByNodeType(), false},
+		{"By exec type", r.ByExecType(), false},
+	}
+	for _, sec := range sections {
+		fmt.Fprintf(tw, "\n%s\n", sec.title)
+		if sec.node {
+			fmt.Fprint(tw, "Name\tType\tExec\t")
+		} else {
+			fmt.Fprint(tw, "Name\t")
+		}
+		fmt.Fprint(tw, "Count\tTotal\tMean\tP50\tP90\tP99\tMax\t% Wall\n")
+		for _, st := range sec.stats {
+			if sec.node {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t", st.Name, st.NodeType, st.ExecType)
+			} else {
+				fmt.Fprintf(tw, "%s\t", st.Name)
+			}
+			fmt.Fprintf(tw, "%d\t%v\t%v\t%v\t%v\t%v\t%v\t%.2f\n",
+				st.Count, st.Total, st.Mean, st.P50, st.P90, st.P99, st.Max, st.PercentWall)
+		}
+	}
+	return tw.Flush()
+}
+
+// WriteCSV writes one row per node, node type and exec type. The group
+// column is "node", "node_type" or "exec_type"; times are in microseconds.
+func (r *ProfileReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"group", "name", "node_type", "exec_type", "count",
+		"total_us", "cpu_total_us", "mean_us", "min_us", "p50_us", "p90_us", "p99_us", "max_us", "percent_wall"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	us := func(d time.Duration) string { return strconv.FormatInt(d.Microseconds(), 10) }
+	groups := []struct {
+		name  string
+		stats []ProfileStats
+	}{
+		{"node", r.Nodes()},
+		{"node_type", r.ByNodeType()},
+		{"exec_type", r.ByExecType()},
+	}
+	for _, g := range groups {
+		for _, st := range g.stats {
+			row := []string{g.name, st.Name, st.NodeType, st.ExecType, strconv.Itoa(st.Count),
+				us(st.Total), us(st.CPUTotal), us(st.Mean), us(st.Min), us(st.P50), us(st.P90), us(st.P99), us(st.Max),
+				strconv.FormatFloat(st.PercentWall, 'f', 2, 64)}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type profileStatsJSON struct {
+	Name        string  `json:"name"`
+	NodeType    string  `json:"node_type,omitempty"`
+	ExecType    string  `json:"exec_type,omitempty"`
+	Count       int     `json:"count"`
+	TotalUs     int64   `json:"total_us"`
+	CPUTotalUs  int64   `json:"cpu_total_us"`
+	MeanUs      int64   `json:"mean_us"`
+	MinUs       int64   `json:"min_us"`
+	P50Us       int64   `json:"p50_us"`
+	P90Us       int64   `json:"p90_us"`
+	P99Us       int64   `json:"p99_us"`
+	MaxUs       int64   `json:"max_us"`
+	PercentWall float64 `json:"percent_wall"`
+}
+
+type profileReportJSON struct {
+	Inferences int                `json:"inferences"`
+	WallTimeUs int64              `json:"wall_time_us"`
+	Nodes      []profileStatsJSON `json:"nodes"`
+	NodeTypes  []profileStatsJSON `json:"node_types"`
+	ExecTypes  []profileStatsJSON `json:"exec_types"`
+}
+
+func statsToJSON(stats []ProfileStats) []profileStatsJSON {
+	out := make([]profileStatsJSON, len(stats))
+	for i, st := range stats {
+		out[i] = profileStatsJSON{
+			Name:        st.Name,
+			NodeType:    st.NodeType,
+			ExecType:    st.ExecType,
+			Count:       st.Count,
+			TotalUs:     st.Total.Microseconds(),
+			CPUTotalUs:  st.CPUTotal.Microseconds(),
+			MeanUs:      st.Mean.Microseconds(),
+			MinUs:       st.Min.Microseconds(),
+			P50Us:       st.P50.Microseconds(),
+			P90Us:       st.P90.Microseconds(),
+			P99Us:       st.P99.Microseconds(),
+			MaxUs:       st.Max.Microseconds(),
+			PercentWall: st.PercentWall,
+		}
+	}
+	return out
+}
+
+// MarshalJSON encodes the report with times in microseconds.
+func (r *ProfileReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(profileReportJSON{
+		Inferences: r.Inferences(),
+		WallTimeUs: r.WallTime().Microseconds(),
+		Nodes:      statsToJSON(r.Nodes()),
+		NodeTypes:  statsToJSON(r.ByNodeType()),
+		ExecTypes:  statsToJSON(r.ByExecType()),
+	})
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *ProfileReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,172 @@
+package openvino
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testProfile(convUs, reluUs int64) []ProfilingInfo {
+	return []ProfilingInfo{
+		{Status: ProfilingInfoStatusExecuted, RealTime: convUs, CPUTime: convUs, NodeName: "conv1", NodeType: "Convolution", ExecType: "jit_avx2_FP32"},
+		{Status: ProfilingInfoStatusExecuted, RealTime: convUs, CPUTime: convUs, NodeName: "conv2", NodeType: "Convolution", ExecType: "jit_avx2_FP32"},
+		{Status: ProfilingInfoStatusExecuted, RealTime: reluUs, CPUTime: reluUs, NodeName: "relu", NodeType: "Relu", ExecType: "ref_FP32"},
+		{Status: ProfilingInfoStatusOptimizedOut, NodeName: "reshape", NodeType: "Reshape", ExecType: "unknown"},
+	}
+}
+
+func testProfileReport() *ProfileReport {
+	r := NewProfileReport()
+	for i := int64(1); i <= 10; i++ {
+		r.Add(testProfile(100*i, 10), 0)
+	}
+	return r
+}
+
+func TestProfileReport_Nodes(t *testing.T) {
+	r := testProfileReport()
+	if r.Inferences() != 10 {
+		t.Errorf("Inferences() = %d, want 10", r.Inferences())
+	}
+	// Each inference: 2 * 100i + 10 microseconds.
+	if want := 11100 * time.Microsecond; r.WallTime() != want {
+		t.Errorf("WallTime() = %v, want %v", r.WallTime(), want)
+	}
+
+	nodes := r.Nodes()
+	if len(nodes) != 3 {
+		t.Fatalf("len(Nodes()) = %d, want 3 (optimized-out nodes are skipped)", len(nodes))
+	}
+	conv := nodes[0]
+	if conv.Name != "conv1" || conv.NodeType != "Convolution" || conv.ExecType != "jit_avx2_FP32" {
+		t.Errorf("slowest node = %+v, want conv1", conv)
+	}
+	if conv.Count != 10 || conv.Total != 5500*time.Microsecond || conv.Mean != 550*time.Microsecond {
+		t.Errorf("conv1 count/total/mean = %d/%v/%v", conv.Count, conv.Total, conv.Mean)
+	}
+	if conv.Min != 100*time.Microsecond || conv.Max != time.Millisecond {
+		t.Errorf("conv1 min/max = %v/%v", conv.Min, conv.Max)
+	}
+	if conv.P50 != 500*time.Microsecond || conv.P90 != 900*time.Microsecond || conv.P99 != time.Millisecond {
+		t.Errorf("conv1 p50/p90/p99 = %v/%v/%v", conv.P50, conv.P90, conv.P99)
+	}
+	if got := conv.PercentWall; got < 49.5 || got > 49.6 {
+		t.Errorf("conv1 PercentWall = %.2f, want ~49.55", got)
+	}
+	if nodes[2].Name != "relu" {
+		t.Errorf("fastest node = %s, want relu", nodes[2].Name)
+	}
+}
+
+func TestProfileReport_boundedSamples(t *testing.T) {
+	r := NewProfileReport()
+	const n = profileMaxSamples + 500
+	for i := int64(1); i <= n; i++ {
+		r.Add(testProfile(i, 10), 0)
+	}
+	if got := len(r.nodes["conv1"].real); got != profileMaxSamples {
+		t.Errorf("kept %d samples, want %d", got, profileMaxSamples)
+	}
+	if got := len(r.nodeTypes["Relu"].real); got != profileMaxSamples {
+		t.Errorf("kept %d node type samples, want %d", got, profileMaxSamples)
+	}
+	for _, st := range r.Nodes() {
+		if st.Name != "conv1" {
+			continue
+		}
+		if st.Count != n || st.Total != n*(n+1)/2*time.Microsecond {
+			t.Errorf("count %d, total %v, want %d and %v", st.Count, st.Total, n, n*(n+1)/2*time.Microsecond)
+		}
+		if st.Min != time.Microsecond || st.Max != n*time.Microsecond {
+			t.Errorf("min %v, max %v, want 1µs and %v", st.Min, st.Max, n*time.Microsecond)
+		}
+		if st.P50 < st.Min || st.P99 > st.Max {
+			t.Errorf("percentiles %v, %v outside [%v, %v]", st.P50, st.P99, st.Min, st.Max)
+		}
+	}
+}
+
+func TestProfileReport_grouped(t *testing.T) {
+	r := testProfileReport()
+
+	types := r.ByNodeType()
+	if len(types) != 2 || types[0].Name != "Convolution" || types[1].Name != "Relu" {
+		t.Fatalf("ByNodeType() = %+v", types)
+	}
+	if types[0].Count != 10 || types[0].Total != 11*time.Millisecond || types[0].Max != 2*time.Millisecond {
+		t.Errorf("Convolution count/total/max = %d/%v/%v", types[0].Count, types[0].Total, types[0].Max)
+	}
+
+	execs := r.ByExecType()
+	if len(execs) != 2 || execs[1].Name != "ref_FP32" || execs[1].Total != 100*time.Microsecond {
+		t.Errorf("ByExecType() = %+v", execs)
+	}
+}
+
+func TestProfileReport_wallTime(t *testing.T) {
+	r := NewProfileReport()
+	r.Add(testProfile(100, 50), time.Millisecond)
+	nodes := r.Nodes()
+	if nodes[0].PercentWall != 10 {
+		t.Errorf("PercentWall = %v, want 10", nodes[0].PercentWall)
+	}
+}
+
+func TestProfileReport_WriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testProfileReport().WriteText(&buf); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Inferences: 10", "By node type", "By exec type", "conv1", "jit_avx2_FP32", "49.55"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestProfileReport_WriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testProfileReport().WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV failed: %v", err)
+	}
+	// header + 3 nodes + 2 node types + 2 exec types
+	if len(rows) != 8 {
+		t.Fatalf("got %d rows, want 8", len(rows))
+	}
+	if rows[1][0] != "node" || rows[1][1] != "conv1" || rows[1][5] != "5500" {
+		t.Errorf("first node row = %v", rows[1])
+	}
+}
+
+func TestProfileReport_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testProfileReport().WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var got struct {
+		Inferences int `json:"inferences"`
+		Nodes      []struct {
+			Name    string `json:"name"`
+			TotalUs int64  `json:"total_us"`
+			P90Us   int64  `json:"p90_us"`
+		} `json:"nodes"`
+		NodeTypes []json.RawMessage `json:"node_types"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decoding JSON failed: %v", err)
+	}
+	if got.Inferences != 10 || len(got.Nodes) != 3 || len(got.NodeTypes) != 2 {
+		t.Fatalf("decoded report = %+v", got)
+	}
+	if got.Nodes[0].Name != "conv1" || got.Nodes[0].TotalUs != 5500 || got.Nodes[0].P90Us != 900 {
+		t.Errorf("first node = %+v", got.Nodes[0])
+	}
+}
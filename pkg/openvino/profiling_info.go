@@ -1,6 +1,10 @@
 package openvino
 
-import "github.com/accretional/openvino-go/internal/cgo"
+import (
+	"runtime"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
 
 type ProfilingInfoStatus = cgo.ProfilingInfoStatus
 
@@ -20,10 +24,16 @@ type ProfilingInfo struct {
 }
 
 func (ir *InferRequest) GetProfilingInfo() ([]ProfilingInfo, error) {
-	cgoInfos, err := ir.request.GetProfilingInfo()
+	const op = "InferRequest.GetProfilingInfo"
+	request, err := ir.handle(op)
 	if err != nil {
 		return nil, err
 	}
+	defer runtime.KeepAlive(ir)
+	cgoInfos, err := request.GetProfilingInfo()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
 
 	infos := make([]ProfilingInfo, len(cgoInfos))
 	for i, cgoInfo := range cgoInfos {
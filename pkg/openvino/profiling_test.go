@@ -2,6 +2,7 @@ package openvino
 
 import (
 	"testing"
+	"time"
 )
 
 func TestInferRequest_GetProfilingInfo(t *testing.T) {
@@ -34,7 +35,7 @@ func TestInferRequest_GetProfilingInfo(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -67,3 +68,50 @@ func TestInferRequest_GetProfilingInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestEnableProfiling_report(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU", EnableProfiling())
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	report := NewProfileReport()
+	for i := 0; i < 3; i++ {
+		if err := req.SetInputTensor("input", []float32{1, 2, 3, 4}, []int64{1, 4}, DataTypeFloat32); err != nil {
+			t.Fatalf("SetInputTensor failed: %v", err)
+		}
+		start := time.Now()
+		if err := req.Infer(); err != nil {
+			t.Fatalf("Infer failed: %v", err)
+		}
+		wall := time.Since(start)
+		infos, err := req.GetProfilingInfo()
+		if err != nil {
+			t.Fatalf("GetProfilingInfo failed: %v", err)
+		}
+		if len(infos) == 0 {
+			t.Fatal("GetProfilingInfo returned no nodes with profiling enabled")
+		}
+		report.Add(infos, wall)
+	}
+
+	if report.Inferences() != 3 {
+		t.Errorf("Inferences() = %d, want 3", report.Inferences())
+	}
+	if len(report.Nodes()) == 0 {
+		t.Error("report has no executed nodes")
+	}
+}
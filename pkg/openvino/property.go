@@ -0,0 +1,185 @@
+package openvino
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
+
+// Well-known property names for use with Core.GetProperty.
+const (
+	PropertyAvailableDevices             = "AVAILABLE_DEVICES"
+	PropertyCacheDir                     = "CACHE_DIR"
+	PropertyDeviceArchitecture           = "DEVICE_ARCHITECTURE"
+	PropertyFullDeviceName               = "FULL_DEVICE_NAME"
+	PropertyLoadedFromCache              = "LOADED_FROM_CACHE"
+	PropertyOptimizationCapabilities     = "OPTIMIZATION_CAPABILITIES"
+	PropertyRangeForAsyncInferRequests   = "RANGE_FOR_ASYNC_INFER_REQUESTS"
+	PropertyRangeForStreams              = "RANGE_FOR_STREAMS"
+	PropertySupportedProperties          = "SUPPORTED_PROPERTIES"
+	PropertyOptimalNumberOfInferRequests = "OPTIMAL_NUMBER_OF_INFER_REQUESTS"
+)
+
+type PropertyKind = cgo.PropertyKind
+
+const (
+	PropertyKindString     = cgo.PropertyKindString
+	PropertyKindInt        = cgo.PropertyKindInt
+	PropertyKindBool       = cgo.PropertyKindBool
+	PropertyKindStringList = cgo.PropertyKindStringList
+	PropertyKindFloat      = cgo.PropertyKindFloat
+)
+
+// PropertyValue is the value of an OpenVINO property. Kind reports the type
+// OpenVINO returned it as; the accessors convert between compatible kinds.
+type PropertyValue struct {
+	Kind PropertyKind
+	str  string
+	i    int64
+	f    float64
+	list []string
+}
+
+// String returns the value as rendered by OpenVINO.
+func (v PropertyValue) String() string {
+	if v.Kind == PropertyKindStringList && v.str == "" {
+		return strings.Join(v.list, " ")
+	}
+	return v.str
+}
+
+// Int returns the value as an integer.
+func (v PropertyValue) Int() (int64, error) {
+	switch v.Kind {
+	case PropertyKindInt, PropertyKindBool:
+		return v.i, nil
+	case PropertyKindFloat:
+		return int64(v.f), nil
+	}
+	i, err := strconv.ParseInt(strings.TrimSpace(v.str), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("openvino: property value %q is not an integer", v.str)
+	}
+	return i, nil
+}
+
+// Float returns the value as a floating point number.
+func (v PropertyValue) Float() (float64, error) {
+	switch v.Kind {
+	case PropertyKindFloat:
+		return v.f, nil
+	case PropertyKindInt, PropertyKindBool:
+		return float64(v.i), nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(v.str), 64)
+	if err != nil {
+		return 0, fmt.Errorf("openvino: property value %q is not a number", v.str)
+	}
+	return f, nil
+}
+
+// Bool returns the value as a boolean. OpenVINO's "YES"/"NO" strings are accepted.
+func (v PropertyValue) Bool() (bool, error) {
+	switch v.Kind {
+	case PropertyKindBool, PropertyKindInt:
+		return v.i != 0, nil
+	}
+	switch strings.ToUpper(strings.TrimSpace(v.str)) {
+	case "YES", "TRUE", "1":
+		return true, nil
+	case "NO", "FALSE", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("openvino: property value %q is not a boolean", v.str)
+}
+
+// Strings returns the value as a list of strings. Scalar values are returned
+// as a single-element list.
+func (v PropertyValue) Strings() []string {
+	if v.Kind == PropertyKindStringList {
+		return append([]string(nil), v.list...)
+	}
+	if v.str == "" {
+		return []string{}
+	}
+	return []string{v.str}
+}
+
+// Ints returns a list value, such as RANGE_FOR_ASYNC_INFER_REQUESTS, as integers.
+func (v PropertyValue) Ints() ([]int64, error) {
+	if v.Kind != PropertyKindStringList {
+		i, err := v.Int()
+		if err != nil {
+			return nil, err
+		}
+		return []int64{i}, nil
+	}
+	ints := make([]int64, len(v.list))
+	for i, s := range v.list {
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("openvino: property list element %q is not an integer", s)
+		}
+		ints[i] = n
+	}
+	return ints, nil
+}
+
+// Duration returns the value as a duration. OpenVINO reports timeouts as
+// integer milliseconds.
+func (v PropertyValue) Duration() (time.Duration, error) {
+	ms, err := v.Int()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+func propertyValueFromCgo(prop cgo.Property) PropertyValue {
+	return PropertyValue{
+		Kind: prop.Kind,
+		str:  prop.String,
+		i:    prop.Int,
+		f:    prop.Float,
+		list: prop.List,
+	}
+}
+
+// GetProperty reads a property of device. Pass an empty device to read a
+// Core-wide property such as CACHE_DIR.
+func (c *Core) GetProperty(device, name string) (PropertyValue, error) {
+	const op = "Core.GetProperty"
+	core, err := c.handle(op)
+	if err != nil {
+		return PropertyValue{}, err
+	}
+	defer runtime.KeepAlive(c)
+	prop, err := core.GetProperty(device, name)
+	if err != nil {
+		return PropertyValue{}, wrapDeviceError(err, nil, op, device)
+	}
+	return propertyValueFromCgo(prop), nil
+}
+
+// SetProperty applies options to device so that later CompileModel calls on
+// that device inherit them. Pass an empty device to set Core-wide properties.
+func (c *Core) SetProperty(device string, options ...CompileOption) error {
+	const op = "Core.SetProperty"
+	core, err := c.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(c)
+	props := make(map[string]string)
+	for _, opt := range options {
+		opt(props)
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	return wrapDeviceError(core.SetProperty(device, props), nil, op, device)
+}
@@ -0,0 +1,138 @@
+package openvino
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPropertyValue_Int(t *testing.T) {
+	v := PropertyValue{Kind: PropertyKindInt, str: "4", i: 4}
+	got, err := v.Int()
+	if err != nil {
+		t.Fatalf("Int failed: %v", err)
+	}
+	if got != 4 {
+		t.Errorf("Int() = %d, want 4", got)
+	}
+
+	v = PropertyValue{Kind: PropertyKindString, str: " 12 "}
+	got, err = v.Int()
+	if err != nil || got != 12 {
+		t.Errorf("Int() on string = %d, %v; want 12, nil", got, err)
+	}
+
+	v = PropertyValue{Kind: PropertyKindString, str: "LATENCY"}
+	if _, err := v.Int(); err == nil {
+		t.Error("Int() on non-numeric string should fail")
+	}
+}
+
+func TestPropertyValue_Bool(t *testing.T) {
+	tests := []struct {
+		v    PropertyValue
+		want bool
+	}{
+		{PropertyValue{Kind: PropertyKindBool, str: "YES", i: 1}, true},
+		{PropertyValue{Kind: PropertyKindBool, str: "NO", i: 0}, false},
+		{PropertyValue{Kind: PropertyKindString, str: "YES"}, true},
+		{PropertyValue{Kind: PropertyKindString, str: "false"}, false},
+	}
+	for _, tt := range tests {
+		got, err := tt.v.Bool()
+		if err != nil {
+			t.Fatalf("Bool() on %q failed: %v", tt.v.str, err)
+		}
+		if got != tt.want {
+			t.Errorf("Bool() on %q = %v, want %v", tt.v.str, got, tt.want)
+		}
+	}
+
+	if _, err := (PropertyValue{Kind: PropertyKindString, str: "maybe"}).Bool(); err == nil {
+		t.Error("Bool() on non-boolean string should fail")
+	}
+}
+
+func TestPropertyValue_Strings(t *testing.T) {
+	v := PropertyValue{Kind: PropertyKindStringList, str: "FP32 INT8", list: []string{"FP32", "INT8"}}
+	got := v.Strings()
+	if len(got) != 2 || got[0] != "FP32" || got[1] != "INT8" {
+		t.Errorf("Strings() = %v, want [FP32 INT8]", got)
+	}
+	if v.String() != "FP32 INT8" {
+		t.Errorf("String() = %q, want %q", v.String(), "FP32 INT8")
+	}
+
+	v = PropertyValue{Kind: PropertyKindString, str: "Intel CPU"}
+	got = v.Strings()
+	if len(got) != 1 || got[0] != "Intel CPU" {
+		t.Errorf("Strings() on scalar = %v, want [Intel CPU]", got)
+	}
+}
+
+func TestPropertyValue_Ints(t *testing.T) {
+	v := PropertyValue{Kind: PropertyKindStringList, list: []string{"1", "8", "1"}}
+	got, err := v.Ints()
+	if err != nil {
+		t.Fatalf("Ints failed: %v", err)
+	}
+	if len(got) != 3 || got[0] != 1 || got[1] != 8 || got[2] != 1 {
+		t.Errorf("Ints() = %v, want [1 8 1]", got)
+	}
+}
+
+func TestPropertyValue_Duration(t *testing.T) {
+	v := PropertyValue{Kind: PropertyKindInt, str: "1000", i: 1000}
+	got, err := v.Duration()
+	if err != nil {
+		t.Fatalf("Duration failed: %v", err)
+	}
+	if got != time.Second {
+		t.Errorf("Duration() = %v, want %v", got, time.Second)
+	}
+}
+
+func TestCore_GetProperty(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	name, err := core.GetProperty("CPU", PropertyFullDeviceName)
+	if err != nil {
+		t.Skipf("GetProperty(CPU, FULL_DEVICE_NAME) failed: %v", err)
+	}
+	if name.String() == "" {
+		t.Error("FULL_DEVICE_NAME should not be empty")
+	}
+
+	supported, err := core.GetProperty("CPU", PropertySupportedProperties)
+	if err != nil {
+		t.Fatalf("GetProperty(CPU, SUPPORTED_PROPERTIES) failed: %v", err)
+	}
+	if supported.Kind != PropertyKindStringList || len(supported.Strings()) == 0 {
+		t.Errorf("SUPPORTED_PROPERTIES = %v (kind %d), want non-empty list", supported.Strings(), supported.Kind)
+	}
+
+	rng, err := core.GetProperty("CPU", PropertyRangeForAsyncInferRequests)
+	if err != nil {
+		t.Fatalf("GetProperty(CPU, RANGE_FOR_ASYNC_INFER_REQUESTS) failed: %v", err)
+	}
+	if ints, err := rng.Ints(); err != nil || len(ints) != 3 {
+		t.Errorf("RANGE_FOR_ASYNC_INFER_REQUESTS = %v, %v; want 3 integers", ints, err)
+	}
+}
+
+func TestCore_SetProperty(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	if err := core.SetProperty("CPU", InferenceNumThreads(2)); err != nil {
+		t.Skipf("SetProperty(CPU) failed: %v", err)
+	}
+
+	threads, err := core.GetProperty("CPU", "INFERENCE_NUM_THREADS")
+	if err != nil {
+		t.Fatalf("GetProperty(CPU, INFERENCE_NUM_THREADS) failed: %v", err)
+	}
+	if n, err := threads.Int(); err != nil || n != 2 {
+		t.Errorf("INFERENCE_NUM_THREADS = %d, %v; want 2", n, err)
+	}
+}
@@ -0,0 +1,245 @@
+package openvino
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// GetInputs describes the inputs of the compiled model the request was
+// created from.
+func (ir *InferRequest) GetInputs() ([]PortInfo, error) {
+	const op = "InferRequest.GetInputs"
+	request, err := ir.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(ir)
+	ports, err := request.GetInputs()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return portInfoFromCgo(ports), nil
+}
+
+// GetOutputs describes the outputs of the compiled model the request was
+// created from.
+func (ir *InferRequest) GetOutputs() ([]PortInfo, error) {
+	const op = "InferRequest.GetOutputs"
+	request, err := ir.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(ir)
+	ports, err := request.GetOutputs()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	return portInfoFromCgo(ports), nil
+}
+
+// ports returns the cached input and output metadata of the request.
+func (ir *InferRequest) ports() (inputs, outputs []PortInfo, err error) {
+	if ir.inputs == nil {
+		if inputs, err = ir.GetInputs(); err != nil {
+			return nil, nil, err
+		}
+		if outputs, err = ir.GetOutputs(); err != nil {
+			return nil, nil, err
+		}
+		ir.inputs, ir.outputs = inputs, outputs
+	}
+	return ir.inputs, ir.outputs, nil
+}
+
+// Run sets inputs, keyed by input tensor name, runs inference and returns
+// every output. Inputs without a name are keyed by "#index" instead, e.g.
+// "#0". The inputs are checked against the model's inputs first: each input
+// needs exactly one tensor of the port's element type and a compatible
+// shape, and unknown keys are rejected. Like InferWithContext,
+// Run cancels the inference if ctx ends.
+//
+// The output tensors share memory with the request, so they are overwritten
+// by its next inference. Close the Outputs once they have been read.
+func (ir *InferRequest) Run(ctx context.Context, inputs map[string]*Tensor) (Outputs, error) {
+	const op = "InferRequest.Run"
+	if err := ctx.Err(); err != nil {
+		return Outputs{}, cancelledError(op, err)
+	}
+	if _, err := ir.handle(op); err != nil {
+		return Outputs{}, err
+	}
+	ports, outputPorts, err := ir.ports()
+	if err != nil {
+		return Outputs{}, err
+	}
+	if err := checkInputs(ports, inputs, op); err != nil {
+		return Outputs{}, err
+	}
+	for i, p := range ports {
+		if p.Name == "" {
+			err = ir.setInputByIndex(int32(i), inputs[inputKey(p, i)], op)
+		} else {
+			err = ir.SetTensor(p.Name, inputs[p.Name])
+		}
+		if err != nil {
+			return Outputs{}, err
+		}
+	}
+	if err := ir.inferContext(ctx, op); err != nil {
+		return Outputs{}, err
+	}
+
+	outputs := Outputs{
+		names:   make([]string, len(outputPorts)),
+		tensors: make([]*Tensor, len(outputPorts)),
+	}
+	for i, p := range outputPorts {
+		tensor, err := ir.GetOutputTensorByIndex(int32(i))
+		if err != nil {
+			outputs.Close()
+			return Outputs{}, err
+		}
+		outputs.names[i] = p.Name
+		outputs.tensors[i] = tensor
+	}
+	return outputs, nil
+}
+
+// setInputByIndex sets tensor as the input at index, for inputs without a
+// name to pass to SetTensor.
+func (ir *InferRequest) setInputByIndex(index int32, tensor *Tensor, op string) error {
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	t, err := tensorArg(tensor, op, indexPort(index))
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	defer runtime.KeepAlive(tensor)
+	if err := request.SetInputTensorUnifiedByIndex(index, t); err != nil {
+		return wrapIndexError(err, ErrInvalidTensor, op, index)
+	}
+	ir.keep("input"+indexPort(index), tensor)
+	return nil
+}
+
+// checkInputs reports every unknown and missing input key, or else the
+// first mismatched tensor, in port order.
+func checkInputs(ports []PortInfo, inputs map[string]*Tensor, op string) error {
+	known := make(map[string]bool, len(ports))
+	var missing []string
+	for i, p := range ports {
+		key := inputKey(p, i)
+		known[key] = true
+		if _, ok := inputs[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	var extra []string
+	for key := range inputs {
+		if !known[key] {
+			extra = append(extra, key)
+		}
+	}
+	if len(extra) > 0 || len(missing) > 0 {
+		sort.Strings(extra)
+		e := &Error{Op: op, kind: ErrParameterMismatch}
+		var problems []string
+		if len(extra) > 0 {
+			e.Code = ErrorCodeNotFound
+			problems = append(problems, fmt.Sprintf("model has no inputs %q", extra))
+		}
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("missing input tensors %q", missing))
+		}
+		e.Message = strings.Join(problems, ", ")
+		switch {
+		case len(extra) == 1 && len(missing) == 0:
+			e.Port = extra[0]
+		case len(missing) == 1 && len(extra) == 0:
+			e.Port = missing[0]
+		}
+		return e
+	}
+
+	for i, p := range ports {
+		key := inputKey(p, i)
+		tensor := inputs[key]
+		if _, err := tensorArg(tensor, op, key); err != nil {
+			return err
+		}
+		dataType, err := tensor.GetElementType()
+		if err != nil {
+			return wrapPortError(err, nil, op, key)
+		}
+		if dataType != p.DataType {
+			return &Error{Message: fmt.Sprintf("element type %s, want %s", dataType, p.DataType), Op: op, Port: key, kind: ErrParameterMismatch}
+		}
+		shape, err := tensor.GetShape()
+		if err != nil {
+			return wrapPortError(err, nil, op, key)
+		}
+		if !p.Shape.Compatible(shape) {
+			return &Error{Message: fmt.Sprintf("shape %v, want %s", shape, p.Shape), Op: op, Port: key, kind: ErrParameterMismatch}
+		}
+	}
+	return nil
+}
+
+// inputKey returns the key of the input port at index in the inputs of Run:
+// its name, or "#index" if it has none.
+func inputKey(p PortInfo, index int) string {
+	if p.Name == "" {
+		return indexPort(int32(index))
+	}
+	return p.Name
+}
+
+// Outputs holds the output tensors of a run, in model output order.
+type Outputs struct {
+	names   []string
+	tensors []*Tensor
+}
+
+// Len returns the number of outputs.
+func (o Outputs) Len() int {
+	return len(o.tensors)
+}
+
+// Names returns the output names, in model output order. Unnamed outputs
+// have an empty name and can only be looked up by index.
+func (o Outputs) Names() []string {
+	return append([]string(nil), o.names...)
+}
+
+// Get returns the output with the given tensor name.
+func (o Outputs) Get(name string) (*Tensor, error) {
+	for i, n := range o.names {
+		if n == name && name != "" {
+			return o.tensors[i], nil
+		}
+	}
+	return nil, &Error{Code: ErrorCodeNotFound, Message: "no such output", Op: "Outputs.Get", Port: name}
+}
+
+// At returns the output at index.
+func (o Outputs) At(index int) (*Tensor, error) {
+	if index < 0 || index >= len(o.tensors) {
+		return nil, &Error{Code: ErrorCodeNotFound, Message: fmt.Sprintf("output index %d out of range [0, %d)", index, len(o.tensors)), Op: "Outputs.At", Port: indexPort(int32(index))}
+	}
+	return o.tensors[index], nil
+}
+
+// Close closes every output tensor. It is safe to call Close more than once.
+func (o Outputs) Close() {
+	for _, t := range o.tensors {
+		if t != nil {
+			t.Close()
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package openvino
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInferRequest_Run(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	input, err := NewTensorWithData(DataTypeFloat32, []int64{1, 4}, []float32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer input.Close()
+
+	outputs, err := req.Run(context.Background(), map[string]*Tensor{"input": input})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	defer outputs.Close()
+
+	if outputs.Len() != 1 || outputs.Names()[0] != "output" {
+		t.Fatalf("outputs %v, want [output]", outputs.Names())
+	}
+	byName, err := outputs.Get("output")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	byIndex, err := outputs.At(0)
+	if err != nil {
+		t.Fatalf("At failed: %v", err)
+	}
+	if byName != byIndex {
+		t.Error("Get and At return different tensors for the same output")
+	}
+	got, err := byName.GetDataAsFloat32()
+	if err != nil {
+		t.Fatalf("GetDataAsFloat32 failed: %v", err)
+	}
+	want := []float32{2, 4, 6, 8}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("output = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestInferRequest_Run_invalidInputs(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	good, err := NewTensor(DataTypeFloat32, []int64{1, 4})
+	if err != nil {
+		t.Fatalf("NewTensor failed: %v", err)
+	}
+	defer good.Close()
+	wrongType, err := NewTensor(DataTypeInt32, []int64{1, 4})
+	if err != nil {
+		t.Fatalf("NewTensor failed: %v", err)
+	}
+	defer wrongType.Close()
+	wrongShape, err := NewTensor(DataTypeFloat32, []int64{1, 5})
+	if err != nil {
+		t.Fatalf("NewTensor failed: %v", err)
+	}
+	defer wrongShape.Close()
+	closed, err := NewTensor(DataTypeFloat32, []int64{1, 4})
+	if err != nil {
+		t.Fatalf("NewTensor failed: %v", err)
+	}
+	closed.Close()
+
+	tests := []struct {
+		name   string
+		inputs map[string]*Tensor
+		want   error
+		port   string
+	}{
+		{"missing", nil, ErrParameterMismatch, "input"},
+		{"extra", map[string]*Tensor{"input": good, "extra": good}, ErrNotFound, "extra"},
+		{"extra and missing", map[string]*Tensor{"a": good, "b": good}, ErrNotFound, ""},
+		{"element type", map[string]*Tensor{"input": wrongType}, ErrParameterMismatch, "input"},
+		{"shape", map[string]*Tensor{"input": wrongShape}, ErrParameterMismatch, "input"},
+		{"nil tensor", map[string]*Tensor{"input": nil}, ErrInvalidTensor, "input"},
+		{"closed tensor", map[string]*Tensor{"input": closed}, ErrClosed, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := req.Run(context.Background(), tt.inputs)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			var e *Error
+			if !errors.As(err, &e) || e.Op != "InferRequest.Run" || e.Port != tt.port {
+				t.Errorf("error %v does not name InferRequest.Run and port %q", err, tt.port)
+			}
+		})
+	}
+}
+
+func TestCheckInputs_keys(t *testing.T) {
+	const op = "InferRequest.Run"
+	ports := []PortInfo{{Name: "ids"}, {}, {Name: "mask"}}
+	err := checkInputs(ports, map[string]*Tensor{"#1": nil, "x": nil, "w": nil}, op)
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, ErrParameterMismatch) {
+		t.Fatalf("unknown and missing keys: got %v, want ErrNotFound and ErrParameterMismatch", err)
+	}
+	for _, want := range []string{`["w" "x"]`, `["ids" "mask"]`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not list %s", err, want)
+		}
+	}
+
+	// With every key present, checking moves on to the tensors.
+	err = checkInputs(ports, map[string]*Tensor{"ids": nil, "#1": nil, "mask": nil}, op)
+	var e *Error
+	if !errors.Is(err, ErrInvalidTensor) || !errors.As(err, &e) || e.Port != "ids" {
+		t.Errorf("nil tensors: got %v, want ErrInvalidTensor for port ids", err)
+	}
+	err = checkInputs([]PortInfo{{}}, map[string]*Tensor{"#0": nil}, op)
+	if !errors.Is(err, ErrInvalidTensor) || !errors.As(err, &e) || e.Port != "#0" {
+		t.Errorf("unnamed input: got %v, want ErrInvalidTensor for port #0", err)
+	}
+}
+
+func TestOutputs(t *testing.T) {
+	first, second := &Tensor{}, &Tensor{}
+	outputs := Outputs{names: []string{"logits", ""}, tensors: []*Tensor{first, second}}
+
+	if outputs.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", outputs.Len())
+	}
+	if got, err := outputs.Get("logits"); err != nil || got != first {
+		t.Errorf("Get(logits) = %p, %v, want %p", got, err, first)
+	}
+	if got, err := outputs.At(1); err != nil || got != second {
+		t.Errorf("At(1) = %p, %v, want %p", got, err, second)
+	}
+	if _, err := outputs.Get(""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of an unnamed output: got %v, want ErrNotFound", err)
+	}
+	if _, err := outputs.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing): got %v, want ErrNotFound", err)
+	}
+	for _, index := range []int{-1, 2} {
+		if _, err := outputs.At(index); !errors.Is(err, ErrNotFound) {
+			t.Errorf("At(%d): got %v, want ErrNotFound", index, err)
+		}
+	}
+
+	names := outputs.Names()
+	names[0] = "changed"
+	if _, err := outputs.Get("logits"); err != nil {
+		t.Error("modifying Names() changed the outputs")
+	}
+	outputs.Close()
+	outputs.Close()
+}
@@ -0,0 +1,383 @@
+package openvino
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
+
+// safeTensorsMaxHeader bounds the JSON header of a .safetensors file, as the
+// reference implementation does.
+const safeTensorsMaxHeader = 100 << 20
+
+// safeTensorsTypes maps safetensors dtype names to data types.
+var safeTensorsTypes = map[string]DataType{
+	"BOOL":    DataTypeBoolean,
+	"U8":      DataTypeUint8,
+	"I8":      DataTypeInt8,
+	"U16":     DataTypeUint16,
+	"I16":     DataTypeInt16,
+	"F16":     DataTypeFloat16,
+	"BF16":    DataTypeBFloat16,
+	"U32":     DataTypeUint32,
+	"I32":     DataTypeInt32,
+	"F32":     DataTypeFloat32,
+	"U64":     DataTypeUint64,
+	"I64":     DataTypeInt64,
+	"F64":     DataTypeFloat64,
+	"F8_E4M3": DataTypeFloat8E4M3,
+	"F8_E5M2": DataTypeFloat8E5M2,
+	"F8_E8M0": DataTypeFloat8E8M0,
+}
+
+// safeTensorInfo describes one tensor in a .safetensors header.
+type safeTensorInfo struct {
+	DType       string   `json:"dtype"`
+	Shape       []int64  `json:"shape"`
+	DataOffsets [2]int64 `json:"data_offsets"`
+}
+
+// fileMapping is a file mapped into memory and shared by the tensors created
+// over it. It is released when the last of them is closed.
+type fileMapping struct {
+	data    []byte
+	release func() error
+	refs    atomic.Int32
+}
+
+func (m *fileMapping) acquire() {
+	m.refs.Add(1)
+}
+
+func (m *fileMapping) unref() {
+	if m.refs.Add(-1) == 0 {
+		m.release()
+	}
+}
+
+// ReadSafeTensors memory-maps a .safetensors file and returns a tensor per
+// entry, keyed by name. The tensors use the mapped file as their memory
+// without copying it; the mapping is released once every tensor is closed.
+// The mapping is private, so writes to the tensors never reach the file.
+// Entries whose dtype has no DataType fail with ErrUnsupportedType.
+func ReadSafeTensors(path string) (map[string]*Tensor, error) {
+	const op = "ReadSafeTensors"
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	data, release, err := mapFile(f, info.Size())
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	m := &fileMapping{data: data, release: release}
+	// The reference held here keeps the mapping alive until every tensor
+	// has taken its own.
+	m.acquire()
+	defer m.unref()
+
+	entries, _, body, err := parseSafeTensors(data)
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	tensors := make(map[string]*Tensor, len(entries))
+	for name, e := range entries {
+		t, err := safeTensor(m, body, e)
+		if err != nil {
+			for _, t := range tensors {
+				t.Close()
+			}
+			return nil, wrapError(fmt.Errorf("tensor %q: %w", name, err), ErrInvalidTensor, op)
+		}
+		tensors[name] = t
+	}
+	return tensors, nil
+}
+
+// ReadSafeTensorsMetadata returns the free-form string metadata stored in
+// the header of a .safetensors file, without reading the tensor data.
+func ReadSafeTensorsMetadata(path string) (map[string]string, error) {
+	const op = "ReadSafeTensorsMetadata"
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
+	defer f.Close()
+	var size [8]byte
+	if _, err := io.ReadFull(f, size[:]); err != nil {
+		return nil, wrapError(fmt.Errorf("reading header size: %w", err), ErrInvalidTensor, op)
+	}
+	n := binary.LittleEndian.Uint64(size[:])
+	if n > safeTensorsMaxHeader {
+		return nil, wrapError(fmt.Errorf("header of %d bytes is too large", n), ErrInvalidTensor, op)
+	}
+	header := make([]byte, 8+n)
+	copy(header, size[:])
+	if _, err := io.ReadFull(f, header[8:]); err != nil {
+		return nil, wrapError(fmt.Errorf("reading header: %w", err), ErrInvalidTensor, op)
+	}
+	_, metadata, _, err := parseSafeTensorsHeader(header, false)
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	return metadata, nil
+}
+
+// WriteSafeTensors writes tensors and optional metadata to w in safetensors
+// format, in name order.
+func WriteSafeTensors(w io.Writer, tensors map[string]*Tensor, metadata map[string]string) error {
+	const op = "WriteSafeTensors"
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	header := make(map[string]interface{}, len(tensors)+1)
+	if len(metadata) > 0 {
+		header["__metadata__"] = metadata
+	}
+	var offset int64
+	contents := make([][]byte, len(names))
+	for i, name := range names {
+		t := tensors[name]
+		tensor, err := tensorArg(t, op, name)
+		if err != nil {
+			return err
+		}
+		e, mem, err := safeTensorEntry(tensor, op)
+		runtime.KeepAlive(t)
+		if err != nil {
+			return wrapError(fmt.Errorf("tensor %q: %w", name, err), nil, op)
+		}
+		e.DataOffsets = [2]int64{offset, offset + int64(len(mem))}
+		offset += int64(len(mem))
+		header[name] = e
+		contents[i] = mem
+	}
+
+	js, err := json.Marshal(header)
+	if err != nil {
+		return wrapError(err, nil, op)
+	}
+	// Pad the header with spaces so the data is 8-byte aligned.
+	for len(js)%8 != 0 {
+		js = append(js, ' ')
+	}
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(js)))
+	if _, err := w.Write(size[:]); err != nil {
+		return wrapError(err, nil, op)
+	}
+	if _, err := w.Write(js); err != nil {
+		return wrapError(err, nil, op)
+	}
+	for i, name := range names {
+		_, err := w.Write(contents[i])
+		runtime.KeepAlive(tensors[name])
+		if err != nil {
+			return wrapError(err, nil, op)
+		}
+	}
+	return nil
+}
+
+// SetStates sets the variable states of a stateful model from tensors keyed
+// by state name, such as initial values read with ReadSafeTensors. The state
+// values are copied, so the tensors may be closed afterwards. It fails with
+// ErrNotFound, before setting any state, if a name matches no state of the
+// request.
+func (ir *InferRequest) SetStates(tensors map[string]*Tensor) error {
+	const op = "InferRequest.SetStates"
+	states, err := ir.QueryState()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, s := range states {
+			s.Close()
+		}
+	}()
+	byName := make(map[string]*VariableState, len(states))
+	for _, s := range states {
+		name, err := s.GetName()
+		if err != nil {
+			return err
+		}
+		byName[name] = s
+	}
+	// Check every name before setting any state, so that a bad name leaves
+	// the request unchanged.
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		if byName[name] == nil {
+			return &Error{Code: ErrorCodeNotFound, Message: "model has no such variable state", Op: op, Port: name}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := byName[name].SetState(tensors[name]); err != nil {
+			return wrapPortError(err, nil, op, name)
+		}
+	}
+	return nil
+}
+
+// safeTensor creates a tensor over the memory of entry e in body, a slice of
+// the mapping m.
+func safeTensor(m *fileMapping, body []byte, e safeTensorInfo) (*Tensor, error) {
+	dataType := safeTensorsTypes[e.DType]
+	begin, end := e.DataOffsets[0], e.DataOffsets[1]
+	if begin == end {
+		return NewTensor(dataType, e.Shape)
+	}
+	tensor, err := cgo.NewTensorFromHostPtr(cgo.DataType(dataType), e.Shape, unsafe.Pointer(&body[begin]))
+	if err != nil {
+		return nil, err
+	}
+	t := newTensor(tensor)
+	m.acquire()
+	t.mapping = m
+	return t, nil
+}
+
+// safeTensorEntry describes tensor for a safetensors header and returns its
+// memory.
+func safeTensorEntry(tensor *cgo.Tensor, op string) (safeTensorInfo, []byte, error) {
+	dataType, err := tensor.GetElementType()
+	if err != nil {
+		return safeTensorInfo{}, nil, err
+	}
+	var e safeTensorInfo
+	for name, dt := range safeTensorsTypes {
+		if dt == dataType {
+			e.DType = name
+		}
+	}
+	if e.DType == "" {
+		return safeTensorInfo{}, nil, fmt.Errorf("%w: data type %v has no safetensors dtype", ErrUnsupportedType, dataType)
+	}
+	if e.Shape, err = tensor.GetShape(); err != nil {
+		return safeTensorInfo{}, nil, err
+	}
+	mem, err := rawView(tensor, op)
+	return e, mem, err
+}
+
+// parseSafeTensors validates a whole .safetensors file and returns its
+// entries, metadata and the data section the entry offsets refer to.
+func parseSafeTensors(data []byte) (map[string]safeTensorInfo, map[string]string, []byte, error) {
+	return parseSafeTensorsHeader(data, true)
+}
+
+// parseSafeTensorsHeader parses the header at the start of data. With
+// checkData set, data holds the whole file and the entries are checked to
+// tile its data section exactly.
+func parseSafeTensorsHeader(data []byte, checkData bool) (map[string]safeTensorInfo, map[string]string, []byte, error) {
+	if len(data) < 8 {
+		return nil, nil, nil, errors.New("file too short for a safetensors header")
+	}
+	n := binary.LittleEndian.Uint64(data)
+	if n > safeTensorsMaxHeader {
+		return nil, nil, nil, fmt.Errorf("header of %d bytes is too large", n)
+	}
+	if uint64(len(data)-8) < n {
+		return nil, nil, nil, fmt.Errorf("header of %d bytes exceeds the file", n)
+	}
+	header := data[8 : 8+n]
+	body := data[8+n:]
+	if !bytes.HasPrefix(header, []byte("{")) {
+		return nil, nil, nil, errors.New("header is not a JSON object")
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(header, &raw); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid header: %w", err)
+	}
+	var metadata map[string]string
+	entries := make(map[string]safeTensorInfo, len(raw))
+	for name, msg := range raw {
+		if name == "__metadata__" {
+			if err := json.Unmarshal(msg, &metadata); err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid metadata: %w", err)
+			}
+			continue
+		}
+		var e safeTensorInfo
+		dec := json.NewDecoder(bytes.NewReader(msg))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&e); err != nil {
+			return nil, nil, nil, fmt.Errorf("tensor %q: invalid entry: %w", name, err)
+		}
+		if err := e.validate(); err != nil {
+			return nil, nil, nil, fmt.Errorf("tensor %q: %w", name, err)
+		}
+		entries[name] = e
+	}
+	if !checkData {
+		return entries, metadata, nil, nil
+	}
+
+	// The entries must cover the data section without gaps or overlaps.
+	sorted := make([]safeTensorInfo, 0, len(entries))
+	for _, e := range entries {
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i].DataOffsets, sorted[j].DataOffsets
+		return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+	})
+	var end int64
+	for _, e := range sorted {
+		if e.DataOffsets[0] != end {
+			return nil, nil, nil, fmt.Errorf("tensor data at offset %d is not contiguous with the previous tensor ending at %d", e.DataOffsets[0], end)
+		}
+		end = e.DataOffsets[1]
+	}
+	if end != int64(len(body)) {
+		return nil, nil, nil, fmt.Errorf("tensors cover %d bytes of a %d byte data section", end, len(body))
+	}
+	return entries, metadata, body, nil
+}
+
+// validate checks the dtype, shape and offsets of e against each other.
+func (e safeTensorInfo) validate() error {
+	dataType, ok := safeTensorsTypes[e.DType]
+	if !ok {
+		return fmt.Errorf("%w: dtype %q", ErrUnsupportedType, e.DType)
+	}
+	for _, d := range e.Shape {
+		if d < 0 {
+			return fmt.Errorf("shape %v has a negative dimension", e.Shape)
+		}
+	}
+	n, ok := elementCount(e.Shape)
+	if !ok || n > (math.MaxInt64-7)/int64(dataType.BitWidth()) {
+		return fmt.Errorf("shape %v is too large", e.Shape)
+	}
+	begin, end := e.DataOffsets[0], e.DataOffsets[1]
+	if begin < 0 || end < begin {
+		return fmt.Errorf("invalid data offsets %v", e.DataOffsets)
+	}
+	if want := dataType.ByteSize(n); end-begin != want {
+		return fmt.Errorf("shape %v of %s needs %d bytes, data offsets %v hold %d", e.Shape, e.DType, want, e.DataOffsets, end-begin)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package openvino
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// safeTensorsFile lays out a .safetensors file from a JSON header and data.
+func safeTensorsFile(header string, data []byte) []byte {
+	b := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+	b = append(b, header...)
+	return append(b, data...)
+}
+
+func TestParseSafeTensors(t *testing.T) {
+	file := safeTensorsFile(`{"__metadata__":{"format":"pt"},"b":{"dtype":"U8","shape":[2],"data_offsets":[8,10]},"a":{"dtype":"F32","shape":[2],"data_offsets":[0,8]}}`, make([]byte, 10))
+	entries, metadata, body, err := parseSafeTensors(file)
+	if err != nil {
+		t.Fatalf("parseSafeTensors failed: %v", err)
+	}
+	if len(body) != 10 || metadata["format"] != "pt" {
+		t.Errorf("body of %d bytes, metadata %v", len(body), metadata)
+	}
+	want := map[string]safeTensorInfo{
+		"a": {DType: "F32", Shape: []int64{2}, DataOffsets: [2]int64{0, 8}},
+		"b": {DType: "U8", Shape: []int64{2}, DataOffsets: [2]int64{8, 10}},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("entries = %+v, want %+v", entries, want)
+	}
+}
+
+func TestParseSafeTensors_invalid(t *testing.T) {
+	tooLarge := binary.LittleEndian.AppendUint64(nil, safeTensorsMaxHeader+1)
+	tests := []struct {
+		name string
+		file []byte
+		kind error
+	}{
+		{"short", []byte{1, 2}, nil},
+		{"header size", tooLarge, nil},
+		{"truncated header", safeTensorsFile(`{"a":{}}`, nil)[:10], nil},
+		{"not an object", safeTensorsFile(`[]`, nil), nil},
+		{"bad json", safeTensorsFile(`{"a":`, nil), nil},
+		{"unknown field", safeTensorsFile(`{"a":{"dtype":"U8","shape":[1],"data_offsets":[0,1],"extra":1}}`, []byte{0}), nil},
+		{"dtype", safeTensorsFile(`{"a":{"dtype":"C64","shape":[1],"data_offsets":[0,8]}}`, make([]byte, 8)), ErrUnsupportedType},
+		{"size", safeTensorsFile(`{"a":{"dtype":"F32","shape":[3],"data_offsets":[0,8]}}`, make([]byte, 8)), nil},
+		{"gap", safeTensorsFile(`{"a":{"dtype":"U8","shape":[1],"data_offsets":[1,2]}}`, make([]byte, 2)), nil},
+		{"overlap", safeTensorsFile(`{"a":{"dtype":"U8","shape":[2],"data_offsets":[0,2]},"b":{"dtype":"U8","shape":[2],"data_offsets":[1,3]}}`, make([]byte, 3)), nil},
+		{"trailing data", safeTensorsFile(`{"a":{"dtype":"U8","shape":[1],"data_offsets":[0,1]}}`, make([]byte, 2)), nil},
+		{"past end", safeTensorsFile(`{"a":{"dtype":"U8","shape":[4],"data_offsets":[0,4]}}`, make([]byte, 2)), nil},
+		{"negative dim", safeTensorsFile(`{"a":{"dtype":"U8","shape":[-1],"data_offsets":[0,0]}}`, nil), nil},
+		{"overflow", safeTensorsFile(`{"a":{"dtype":"F32","shape":[4611686018427387904,4],"data_offsets":[0,0]}}`, nil), nil},
+	}
+	for _, tt := range tests {
+		_, _, _, err := parseSafeTensors(tt.file)
+		if err == nil {
+			t.Errorf("%s: parseSafeTensors succeeded, want an error", tt.name)
+		} else if tt.kind != nil && !errors.Is(err, tt.kind) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.kind)
+		}
+	}
+}
+
+func TestSafeTensors(t *testing.T) {
+	coreAvailable(t).Close()
+
+	weights, err := NewTensorWithData(DataTypeFloat32, []int64{2, 2}, []float32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer weights.Close()
+	ids, err := NewTensorWithData(DataTypeInt64, []int64{3}, []int64{-1, 0, 1})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer ids.Close()
+
+	var buf bytes.Buffer
+	if err := WriteSafeTensors(&buf, map[string]*Tensor{"weights": weights, "ids": ids}, map[string]string{"format": "pt"}); err != nil {
+		t.Fatalf("WriteSafeTensors failed: %v", err)
+	}
+	if n := binary.LittleEndian.Uint64(buf.Bytes()); n%8 != 0 {
+		t.Errorf("header length %d is not 8-byte aligned", n)
+	}
+	path := filepath.Join(t.TempDir(), "adapter.safetensors")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := ReadSafeTensorsMetadata(path)
+	if err != nil {
+		t.Fatalf("ReadSafeTensorsMetadata failed: %v", err)
+	}
+	if metadata["format"] != "pt" {
+		t.Errorf("metadata = %v, want format=pt", metadata)
+	}
+
+	tensors, err := ReadSafeTensors(path)
+	if err != nil {
+		t.Fatalf("ReadSafeTensors failed: %v", err)
+	}
+	if len(tensors) != 2 {
+		t.Fatalf("ReadSafeTensors returned %d tensors, want 2", len(tensors))
+	}
+	w, err := TensorView[float32](tensors["weights"])
+	if err != nil {
+		t.Fatalf("TensorView failed: %v", err)
+	}
+	if !reflect.DeepEqual(w, []float32{1, 2, 3, 4}) {
+		t.Errorf("weights = %v, want [1 2 3 4]", w)
+	}
+	if got, _ := tensors["ids"].GetDataAsInt64(); !reflect.DeepEqual(got, []int64{-1, 0, 1}) {
+		t.Errorf("ids = %v, want [-1 0 1]", got)
+	}
+
+	// Writes stay private to the mapping.
+	w[0] = 100
+	for _, tensor := range tensors {
+		tensor.Close()
+	}
+	again, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, buf.Bytes()) {
+		t.Error("writing to a tensor changed the file")
+	}
+}
+
+func TestInferRequest_SetStates(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	if err := req.SetStates(nil); err != nil {
+		t.Errorf("SetStates(nil) failed: %v", err)
+	}
+	tensor, err := NewTensor(DataTypeFloat32, []int64{1})
+	if err != nil {
+		t.Fatalf("NewTensor failed: %v", err)
+	}
+	defer tensor.Close()
+	if err := req.SetStates(map[string]*Tensor{"missing": tensor}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetStates with an unknown state: got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,242 @@
+package openvino
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
+
+// Dimension is one dimension of a PartialShape. It is static when Min equals
+// Max; otherwise it is the interval Min..Max, where a Max of -1 is unbounded.
+type Dimension struct {
+	Min int64
+	Max int64
+}
+
+// Dim returns a static dimension of length n.
+func Dim(n int64) Dimension {
+	return Dimension{Min: n, Max: n}
+}
+
+// DynamicDim returns a fully dynamic dimension.
+func DynamicDim() Dimension {
+	return Dimension{Min: 0, Max: -1}
+}
+
+// DimRange returns a dimension bounded to min..max. Pass -1 as max to leave
+// the upper bound open.
+func DimRange(min, max int64) Dimension {
+	return Dimension{Min: min, Max: max}
+}
+
+// IsStatic reports whether the dimension has a single known length.
+func (d Dimension) IsStatic() bool {
+	return d.Min == d.Max && d.Min >= 0
+}
+
+// IsDynamic reports whether the dimension's length is not fixed.
+func (d Dimension) IsDynamic() bool {
+	return !d.IsStatic()
+}
+
+// Length returns the length of a static dimension, or -1 if it is dynamic.
+func (d Dimension) Length() int64 {
+	if d.IsStatic() {
+		return d.Min
+	}
+	return -1
+}
+
+// Compatible reports whether a concrete length n fits within the dimension.
+func (d Dimension) Compatible(n int64) bool {
+	return n >= d.Min && (d.Max == -1 || n <= d.Max)
+}
+
+// String formats the dimension the way OpenVINO does: "4", "?", "1..512",
+// "2.." or "..8".
+func (d Dimension) String() string {
+	switch {
+	case d.IsStatic():
+		return strconv.FormatInt(d.Min, 10)
+	case d.Min <= 0 && d.Max == -1:
+		return "?"
+	case d.Max == -1:
+		return strconv.FormatInt(d.Min, 10) + ".."
+	case d.Min <= 0:
+		return ".." + strconv.FormatInt(d.Max, 10)
+	}
+	return strconv.FormatInt(d.Min, 10) + ".." + strconv.FormatInt(d.Max, 10)
+}
+
+// ParseDimension parses a dimension in the format produced by
+// Dimension.String. "-1" is accepted as a dynamic dimension.
+func ParseDimension(s string) (Dimension, error) {
+	s = strings.TrimSpace(s)
+	if s == "?" || s == "-1" {
+		return DynamicDim(), nil
+	}
+	lo, hi, isRange := strings.Cut(s, "..")
+	if !isRange {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n < 0 {
+			return Dimension{}, fmt.Errorf("openvino: invalid dimension %q", s)
+		}
+		return Dim(n), nil
+	}
+	d := DynamicDim()
+	var err error
+	if lo != "" {
+		if d.Min, err = strconv.ParseInt(lo, 10, 64); err != nil {
+			return Dimension{}, fmt.Errorf("openvino: invalid dimension %q", s)
+		}
+	}
+	if hi != "" {
+		if d.Max, err = strconv.ParseInt(hi, 10, 64); err != nil {
+			return Dimension{}, fmt.Errorf("openvino: invalid dimension %q", s)
+		}
+	}
+	if err := d.validate(); err != nil {
+		return Dimension{}, err
+	}
+	return d, nil
+}
+
+func (d Dimension) validate() error {
+	if d.Min < 0 {
+		return fmt.Errorf("openvino: dimension lower bound %d is negative", d.Min)
+	}
+	if d.Max != -1 && d.Max < d.Min {
+		return fmt.Errorf("openvino: dimension upper bound %d is below lower bound %d", d.Max, d.Min)
+	}
+	return nil
+}
+
+// PartialShape is a shape whose dimensions may be dynamic or bounded. A nil
+// PartialShape has a dynamic rank; an empty non-nil one is a scalar.
+type PartialShape []Dimension
+
+// NewPartialShape builds a PartialShape from lengths, where -1 marks a
+// dynamic dimension.
+func NewPartialShape(dims ...int64) PartialShape {
+	ps := make(PartialShape, len(dims))
+	for i, d := range dims {
+		if d < 0 {
+			ps[i] = DynamicDim()
+		} else {
+			ps[i] = Dim(d)
+		}
+	}
+	return ps
+}
+
+// IsRankDynamic reports whether even the number of dimensions is unknown.
+func (ps PartialShape) IsRankDynamic() bool {
+	return ps == nil
+}
+
+// IsStatic reports whether the rank and every dimension are known.
+func (ps PartialShape) IsStatic() bool {
+	if ps.IsRankDynamic() {
+		return false
+	}
+	for _, d := range ps {
+		if !d.IsStatic() {
+			return false
+		}
+	}
+	return true
+}
+
+// ToShape returns the concrete shape of a static PartialShape.
+func (ps PartialShape) ToShape() ([]int64, error) {
+	if !ps.IsStatic() {
+		return nil, fmt.Errorf("openvino: shape %s is not static", ps)
+	}
+	shape := make([]int64, len(ps))
+	for i, d := range ps {
+		shape[i] = d.Min
+	}
+	return shape, nil
+}
+
+// Compatible reports whether the concrete shape fits the PartialShape.
+func (ps PartialShape) Compatible(shape []int64) bool {
+	if ps.IsRankDynamic() {
+		return true
+	}
+	if len(shape) != len(ps) {
+		return false
+	}
+	for i, d := range ps {
+		if !d.Compatible(shape[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the shape the way OpenVINO does, e.g. "[1,?,768]" or
+// "[1,1..512]". A dynamic rank is "[...]".
+func (ps PartialShape) String() string {
+	if ps.IsRankDynamic() {
+		return "[...]"
+	}
+	dims := make([]string, len(ps))
+	for i, d := range ps {
+		dims[i] = d.String()
+	}
+	return "[" + strings.Join(dims, ",") + "]"
+}
+
+// ParsePartialShape parses a shape in the format produced by
+// PartialShape.String. Brackets are optional and dimensions may be
+// separated by commas or spaces.
+func ParsePartialShape(s string) (PartialShape, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	if strings.TrimSpace(s) == "..." {
+		return nil, nil
+	}
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	ps := make(PartialShape, len(fields))
+	for i, f := range fields {
+		d, err := ParseDimension(f)
+		if err != nil {
+			return nil, fmt.Errorf("openvino: parsing shape %q: %w", s, err)
+		}
+		ps[i] = d
+	}
+	return ps, nil
+}
+
+// bounds splits the shape into per-dimension lower and upper bounds.
+func (ps PartialShape) bounds() ([]int64, []int64, error) {
+	if ps.IsRankDynamic() {
+		return nil, nil, fmt.Errorf("openvino: shape with dynamic rank is not supported")
+	}
+	mins := make([]int64, len(ps))
+	maxs := make([]int64, len(ps))
+	for i, d := range ps {
+		if err := d.validate(); err != nil {
+			return nil, nil, err
+		}
+		mins[i] = d.Min
+		maxs[i] = d.Max
+	}
+	return mins, maxs, nil
+}
+
+func partialShapeFromCgo(dims []cgo.Dimension) PartialShape {
+	if dims == nil {
+		return nil
+	}
+	ps := make(PartialShape, len(dims))
+	for i, d := range dims {
+		ps[i] = Dimension{Min: d.Min, Max: d.Max}
+	}
+	return ps
+}
@@ -0,0 +1,122 @@
+package openvino
+
+import "testing"
+
+func TestNewPartialShape(t *testing.T) {
+	ps := NewPartialShape(1, -1, 768)
+	want := PartialShape{Dim(1), DynamicDim(), Dim(768)}
+	if len(ps) != len(want) {
+		t.Fatalf("len = %d, want %d", len(ps), len(want))
+	}
+	for i := range want {
+		if ps[i] != want[i] {
+			t.Errorf("dim %d = %+v, want %+v", i, ps[i], want[i])
+		}
+	}
+}
+
+func TestPartialShape_bounds(t *testing.T) {
+	mins, maxs, err := PartialShape{Dim(1), DimRange(1, 512), DynamicDim()}.bounds()
+	if err != nil {
+		t.Fatalf("bounds failed: %v", err)
+	}
+	wantMins := []int64{1, 1, 0}
+	wantMaxs := []int64{1, 512, -1}
+	for i := range wantMins {
+		if mins[i] != wantMins[i] || maxs[i] != wantMaxs[i] {
+			t.Errorf("dim %d = %d..%d, want %d..%d", i, mins[i], maxs[i], wantMins[i], wantMaxs[i])
+		}
+	}
+
+	if _, _, err := (PartialShape{DimRange(8, 4)}).bounds(); err == nil {
+		t.Error("bounds with max < min should fail")
+	}
+	if _, _, err := (PartialShape{DimRange(-2, 4)}).bounds(); err == nil {
+		t.Error("bounds with negative min should fail")
+	}
+}
+
+func TestPartialShape_String(t *testing.T) {
+	tests := []struct {
+		ps   PartialShape
+		want string
+	}{
+		{PartialShape{Dim(1), DynamicDim(), Dim(768)}, "[1,?,768]"},
+		{PartialShape{Dim(1), DimRange(1, 512)}, "[1,1..512]"},
+		{PartialShape{DimRange(2, -1), DimRange(0, 8)}, "[2..,..8]"},
+		{PartialShape{}, "[]"},
+		{nil, "[...]"},
+	}
+	for _, tt := range tests {
+		if got := tt.ps.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestParsePartialShape(t *testing.T) {
+	tests := []struct {
+		in   string
+		want PartialShape
+	}{
+		{"[1,?,768]", PartialShape{Dim(1), DynamicDim(), Dim(768)}},
+		{"[1,1..512]", PartialShape{Dim(1), DimRange(1, 512)}},
+		{"1 -1 3", PartialShape{Dim(1), DynamicDim(), Dim(3)}},
+		{"[2..,..8]", PartialShape{DimRange(2, -1), DimRange(0, 8)}},
+		{"[]", PartialShape{}},
+		{"[...]", nil},
+	}
+	for _, tt := range tests {
+		got, err := ParsePartialShape(tt.in)
+		if err != nil {
+			t.Errorf("ParsePartialShape(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got.String() != tt.want.String() || got.IsRankDynamic() != tt.want.IsRankDynamic() {
+			t.Errorf("ParsePartialShape(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"[1,x]", "[8..4]", "[-2]", "[1..2..3]"} {
+		if _, err := ParsePartialShape(in); err == nil {
+			t.Errorf("ParsePartialShape(%q) should fail", in)
+		}
+	}
+}
+
+func TestPartialShape_Compatible(t *testing.T) {
+	ps := PartialShape{Dim(1), DimRange(1, 512), DynamicDim()}
+	if !ps.Compatible([]int64{1, 128, 768}) {
+		t.Error("[1,128,768] should be compatible")
+	}
+	if ps.Compatible([]int64{1, 1024, 768}) {
+		t.Error("[1,1024,768] exceeds the bound and should not be compatible")
+	}
+	if ps.Compatible([]int64{2, 128, 768}) {
+		t.Error("[2,128,768] should not be compatible")
+	}
+	if ps.Compatible([]int64{1, 128}) {
+		t.Error("rank mismatch should not be compatible")
+	}
+	if !PartialShape(nil).Compatible([]int64{3, 4}) {
+		t.Error("dynamic rank should accept any shape")
+	}
+}
+
+func TestPartialShape_ToShape(t *testing.T) {
+	ps := PartialShape{Dim(1), Dim(3000000000)}
+	if !ps.IsStatic() {
+		t.Fatal("IsStatic() = false, want true")
+	}
+	shape, err := ps.ToShape()
+	if err != nil {
+		t.Fatalf("ToShape failed: %v", err)
+	}
+	if len(shape) != 2 || shape[0] != 1 || shape[1] != 3000000000 {
+		t.Errorf("ToShape() = %v, want [1 3000000000]", shape)
+	}
+
+	if _, err := NewPartialShape(1, -1).ToShape(); err == nil {
+		t.Error("ToShape of a dynamic shape should fail")
+	}
+}
@@ -1,115 +1,741 @@
 package openvino
 
-import "github.com/accretional/openvino-go/internal/cgo"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"unsafe"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
 
 type Tensor struct {
 	tensor *cgo.Tensor
+	// pinner pins the Go memory of tensors made by NewTensorFromSlice.
+	pinner *runtime.Pinner
+	// parent is the tensor a region of interest was taken from, kept
+	// reachable because it may own the memory.
+	parent *Tensor
+	// mapping is the mapped file holding the memory of tensors read by
+	// ReadSafeTensors.
+	mapping *fileMapping
+}
+
+func newTensor(tensor *cgo.Tensor) *Tensor {
+	return trackHandle(&Tensor{tensor: tensor}, "Tensor", (*Tensor).Close)
 }
 
 func (ir *InferRequest) GetOutputTensor(name string) (*Tensor, error) {
-	tensor, err := ir.request.GetOutputTensor(name)
+	const op = "InferRequest.GetOutputTensor"
+	request, err := ir.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &Tensor{tensor: tensor}, nil
+	defer runtime.KeepAlive(ir)
+	tensor, err := request.GetOutputTensor(name)
+	if err != nil {
+		return nil, wrapPortError(err, ErrInvalidTensor, op, name)
+	}
+	return newTensor(tensor), nil
 }
 
 func (ir *InferRequest) GetOutputTensorByIndex(index int32) (*Tensor, error) {
-	tensor, err := ir.request.GetOutputTensorByIndex(index)
+	const op = "InferRequest.GetOutputTensorByIndex"
+	request, err := ir.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &Tensor{tensor: tensor}, nil
+	defer runtime.KeepAlive(ir)
+	tensor, err := request.GetOutputTensorByIndex(index)
+	if err != nil {
+		return nil, wrapIndexError(err, ErrInvalidTensor, op, index)
+	}
+	return newTensor(tensor), nil
 }
 
+// Close releases the tensor. It is safe to call Close more than once.
 func (t *Tensor) Close() {
 	if t.tensor != nil {
+		runtime.SetFinalizer(t, nil)
 		t.tensor.Destroy()
+		t.tensor = nil
+	}
+	if t.pinner != nil {
+		t.pinner.Unpin()
+		t.pinner = nil
+	}
+	t.parent = nil
+	if t.mapping != nil {
+		t.mapping.unref()
+		t.mapping = nil
+	}
+}
+
+func (t *Tensor) handle(op string) (*cgo.Tensor, error) {
+	if t.tensor == nil {
+		return nil, closedError(op, "Tensor")
+	}
+	return t.tensor, nil
+}
+
+// tensorArg returns the handle of a tensor passed to op for port.
+func tensorArg(t *Tensor, op, port string) (*cgo.Tensor, error) {
+	if t == nil {
+		return nil, wrapPortError(errors.New("tensor cannot be nil"), ErrInvalidTensor, op, port)
 	}
+	return t.handle(op)
+}
+
+// tensorArgs is tensorArg for a batch of tensors.
+func tensorArgs(tensors []*Tensor, op, port string) ([]*cgo.Tensor, error) {
+	cgoTensors := make([]*cgo.Tensor, len(tensors))
+	for i, t := range tensors {
+		tensor, err := tensorArg(t, op, port)
+		if err != nil {
+			return nil, err
+		}
+		cgoTensors[i] = tensor
+	}
+	return cgoTensors, nil
 }
 
 func (t *Tensor) GetDataAsFloat32() ([]float32, error) {
-	return t.tensor.GetDataAsFloat32()
+	return rawData[float32](t, "Tensor.GetDataAsFloat32")
 }
 
 func (t *Tensor) GetDataAsInt64() ([]int64, error) {
-	return t.tensor.GetDataAsInt64()
+	return rawData[int64](t, "Tensor.GetDataAsInt64")
 }
 
 func (t *Tensor) GetDataAsFloat64() ([]float64, error) {
-	return t.tensor.GetDataAsFloat64()
+	return rawData[float64](t, "Tensor.GetDataAsFloat64")
 }
 
 func (t *Tensor) GetDataAsInt32() ([]int32, error) {
-	return t.tensor.GetDataAsInt32()
+	return rawData[int32](t, "Tensor.GetDataAsInt32")
 }
 
 func (t *Tensor) GetDataAsUint8() ([]uint8, error) {
-	return t.tensor.GetDataAsUint8()
+	return rawData[uint8](t, "Tensor.GetDataAsUint8")
 }
 
 func (t *Tensor) GetDataAsInt8() ([]int8, error) {
-	return t.tensor.GetDataAsInt8()
+	return rawData[int8](t, "Tensor.GetDataAsInt8")
 }
 
 func (t *Tensor) GetDataAsUint16() ([]uint16, error) {
-	return t.tensor.GetDataAsUint16()
+	return rawData[uint16](t, "Tensor.GetDataAsUint16")
 }
 
 func (t *Tensor) GetDataAsInt16() ([]int16, error) {
-	return t.tensor.GetDataAsInt16()
+	return rawData[int16](t, "Tensor.GetDataAsInt16")
 }
 
 func (t *Tensor) GetDataAsUint32() ([]uint32, error) {
-	return t.tensor.GetDataAsUint32()
+	return rawData[uint32](t, "Tensor.GetDataAsUint32")
 }
 
 func (t *Tensor) GetDataAsUint64() ([]uint64, error) {
-	return t.tensor.GetDataAsUint64()
+	return rawData[uint64](t, "Tensor.GetDataAsUint64")
+}
+
+func (t *Tensor) GetDataAsFloat16() ([]Float16, error) {
+	return tensorData[Float16](t, "Tensor.GetDataAsFloat16")
+}
+
+func (t *Tensor) GetDataAsBFloat16() ([]BFloat16, error) {
+	return tensorData[BFloat16](t, "Tensor.GetDataAsBFloat16")
+}
+
+// GetDataAsBool returns the elements of a DataTypeBoolean tensor.
+func (t *Tensor) GetDataAsBool() ([]bool, error) {
+	const op = "Tensor.GetDataAsBool"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	v, err := viewAs[uint8](tensor, op, DataTypeBoolean)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]bool, len(v))
+	for i, b := range v {
+		out[i] = b != 0
+	}
+	return out, nil
 }
 
-func (t *Tensor) GetShape() ([]int32, error) {
-	return t.tensor.GetShape()
+// GetDataAsString returns the elements of a DataTypeString tensor.
+func (t *Tensor) GetDataAsString() ([]string, error) {
+	const op = "Tensor.GetDataAsString"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	data, err := tensor.GetStrings()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	return data, nil
+}
+
+// GetDataAsBytes returns a copy of the tensor's memory. Packed sub-byte
+// types such as DataTypeUint4 or DataTypeNF4 are returned packed, in
+// OpenVINO's layout; the length is the tensor's byte size.
+func (t *Tensor) GetDataAsBytes() ([]byte, error) {
+	const op = "Tensor.GetDataAsBytes"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	dataType, err := tensor.GetElementType()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	if dataType == DataTypeString {
+		return nil, wrapError(errors.New("string tensors have no byte representation"), ErrUnsupportedType, op)
+	}
+	if err := checkContinuous(tensor, op); err != nil {
+		return nil, err
+	}
+	size, err := tensor.GetByteSize()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	if size == 0 {
+		return []byte{}, nil
+	}
+	data, err := tensor.GetData()
+	return data, wrapError(err, ErrInvalidTensor, op)
+}
+
+// GetDataConvertedToFloat32 returns the elements of t converted to float32,
+// whatever its numeric element type. Use it to read half-precision outputs
+// without handling Float16 or BFloat16 values.
+func (t *Tensor) GetDataConvertedToFloat32() ([]float32, error) {
+	const op = "Tensor.GetDataConvertedToFloat32"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	dataType, err := tensor.GetElementType()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	switch DataType(dataType) {
+	case DataTypeFloat32:
+		return tensorData[float32](t, op)
+	case DataTypeFloat16:
+		return float32Data(tensor, op, ConvertFromFloat16)
+	case DataTypeBFloat16:
+		return float32Data(tensor, op, ConvertFromBFloat16)
+	case DataTypeFloat64:
+		return float32Data(tensor, op, widen[float64])
+	case DataTypeInt8:
+		return float32Data(tensor, op, widen[int8])
+	case DataTypeInt16:
+		return float32Data(tensor, op, widen[int16])
+	case DataTypeInt32:
+		return float32Data(tensor, op, widen[int32])
+	case DataTypeInt64:
+		return float32Data(tensor, op, widen[int64])
+	case DataTypeUint8:
+		return float32Data(tensor, op, widen[uint8])
+	case DataTypeUint16:
+		return float32Data(tensor, op, widen[uint16])
+	case DataTypeUint32:
+		return float32Data(tensor, op, widen[uint32])
+	case DataTypeUint64:
+		return float32Data(tensor, op, widen[uint64])
+	}
+	return nil, wrapError(fmt.Errorf("cannot convert data type %v to float32", DataType(dataType)), ErrUnsupportedType, op)
+}
+
+// float32Data returns the elements of tensor, stored as T, converted to
+// float32 by convert.
+func float32Data[T Element](tensor *cgo.Tensor, op string, convert func([]float32, []T) int) ([]float32, error) {
+	v, err := view[T](tensor, op)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float32, len(v))
+	convert(out, v)
+	return out, nil
+}
+
+func widen[T float64 | int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64](dst []float32, src []T) int {
+	n := min(len(dst), len(src))
+	for i, v := range src[:n] {
+		dst[i] = float32(v)
+	}
+	return n
+}
+
+func (t *Tensor) GetShape() ([]int64, error) {
+	const op = "Tensor.GetShape"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	shape, err := tensor.GetShape()
+	return shape, wrapError(err, ErrInvalidTensor, op)
+}
+
+// ROI returns a tensor viewing the region of t from begin, inclusive, to
+// end, exclusive, in each dimension. No data is copied: writes through
+// either tensor are seen by the other. The region can be set on an infer
+// request like any tensor, for example to run inference on a tile of a large
+// image. A region is generally not contiguous in memory, so TensorView and
+// the GetDataAs methods reject it; copy it with CopyTo to read it. If t was
+// made by NewTensorFromSlice it must stay open while the region is used.
+func (t *Tensor) ROI(begin, end []int64) (*Tensor, error) {
+	const op = "Tensor.ROI"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	roi, err := tensor.ROI(begin, end)
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	r := newTensor(roi)
+	r.parent = t
+	return r, nil
+}
+
+// rawData returns a copy of the memory of t reinterpreted as []T, whatever
+// the tensor's element type, as the GetDataAs methods always have.
+func rawData[T any](t *Tensor, op string) ([]T, error) {
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	raw, err := rawView(tensor, op)
+	if err != nil {
+		return nil, err
+	}
+	size := int(unsafe.Sizeof(*new(T)))
+	data := make([]T, len(raw)/size)
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(data))), len(data)*size), raw)
+	return data, nil
+}
+
+// CopyTo copies the elements of t into dst, which must have the same element
+// type. dst is reshaped to the shape of t unless it is a region of interest,
+// whose shape must already match. Either tensor may be a region of interest,
+// so CopyTo can gather a tile into a compact tensor or copy an output into a
+// preallocated buffer made by NewTensorFromSlice.
+func (t *Tensor) CopyTo(dst *Tensor) error {
+	const op = "Tensor.CopyTo"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return err
+	}
+	d, err := tensorArg(dst, op, "")
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(t)
+	defer runtime.KeepAlive(dst)
+	return wrapError(tensor.CopyTo(d), ErrInvalidTensor, op)
+}
+
+// Strides returns, for each dimension, the distance in bytes between
+// consecutive elements. A region of interest has the strides of the tensor
+// it was taken from.
+func (t *Tensor) Strides() ([]int64, error) {
+	const op = "Tensor.Strides"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	strides, err := tensor.GetStrides()
+	return strides, wrapError(err, ErrInvalidTensor, op)
+}
+
+// IsContinuous reports whether the elements of t are contiguous in memory.
+// It is false for most regions of interest.
+func (t *Tensor) IsContinuous() (bool, error) {
+	const op = "Tensor.IsContinuous"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return false, err
+	}
+	defer runtime.KeepAlive(t)
+	continuous, err := tensor.IsContinuous()
+	return continuous, wrapError(err, ErrInvalidTensor, op)
 }
 
 // NewTensor creates a new empty tensor with the specified data type and shape.
 func NewTensor(dataType DataType, shape []int64) (*Tensor, error) {
 	tensor, err := cgo.NewTensor(cgo.DataType(dataType), shape)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err, ErrInvalidTensor, "NewTensor")
 	}
-	return &Tensor{tensor: tensor}, nil
+	return newTensor(tensor), nil
 }
 
 // NewTensorWithData creates a new tensor with the specified data type, shape, and initial data.
+// Half-precision data may be given as []Float16 or []BFloat16, or as []float32
+// to be converted to dataType.
 func NewTensorWithData(dataType DataType, shape []int64, data interface{}) (*Tensor, error) {
+	const op = "NewTensorWithData"
+	if v, ok := data.([]string); ok && dataType == DataTypeString {
+		t, err := NewTensorFromStrings(shape, v)
+		return t, wrapError(err, nil, op)
+	}
+	data, err := hostData(data, dataType, shape)
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
 	tensor, err := cgo.NewTensorWithData(cgo.DataType(dataType), shape, data)
 	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	return newTensor(tensor), nil
+}
+
+// NewTensorFromStrings creates a DataTypeString tensor, such as the input of
+// a tokenizer model, holding a copy of data.
+func NewTensorFromStrings(shape []int64, data []string) (*Tensor, error) {
+	const op = "NewTensorFromStrings"
+	t, err := NewTensor(DataTypeString, shape)
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	if err := t.tensor.SetStrings(data); err != nil {
+		t.Close()
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	return t, nil
+}
+
+// NewTensorFromFloat32 creates a tensor of dataType, which must be
+// DataTypeFloat32, DataTypeFloat16 or DataTypeBFloat16, holding data
+// converted to that type. Use it to feed models with half-precision inputs.
+func NewTensorFromFloat32(dataType DataType, shape []int64, data []float32) (*Tensor, error) {
+	const op = "NewTensorFromFloat32"
+	t, err := NewTensor(dataType, shape)
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	size, err := t.tensor.GetSize()
+	if err != nil {
+		t.Close()
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	if int64(len(data)) != size {
+		t.Close()
+		return nil, wrapError(fmt.Errorf("shape %v needs %d elements, got %d", shape, size, len(data)), ErrInvalidTensor, op)
+	}
+	switch dataType {
+	case DataTypeFloat32:
+		err = fill(t.tensor, op, data, func(dst, src []float32) int { return copy(dst, src) })
+	case DataTypeFloat16:
+		err = fill(t.tensor, op, data, ConvertToFloat16)
+	case DataTypeBFloat16:
+		err = fill(t.tensor, op, data, ConvertToBFloat16)
+	default:
+		err = wrapError(fmt.Errorf("cannot convert float32 to data type %v", dataType), ErrUnsupportedType, op)
+	}
+	if err != nil {
+		t.Close()
 		return nil, err
 	}
-	return &Tensor{tensor: tensor}, nil
+	return t, nil
+}
+
+// fill converts data into the memory of tensor, stored as T.
+func fill[T Element](tensor *cgo.Tensor, op string, data []float32, convert func([]T, []float32) int) error {
+	v, err := view[T](tensor, op)
+	if err != nil {
+		return err
+	}
+	convert(v, data)
+	return nil
+}
+
+// hostData prepares data for the wrapper calls that copy it into a new
+// tensor of dataType and shape: half-precision slices are passed as their
+// bits, []bool as bytes, and []float32 bound for a half-precision tensor is
+// converted first. Packed sub-byte types take their bytes as []uint8. It
+// fails if data is too short for the tensor.
+func hostData(data interface{}, dataType DataType, shape []int64) (interface{}, error) {
+	switch v := data.(type) {
+	case []Float16:
+		data = bits(v)
+	case []BFloat16:
+		data = bits(v)
+	case []bool:
+		data = boolBytes(v)
+	case []float32:
+		switch dataType {
+		case DataTypeFloat16:
+			h := make([]Float16, len(v))
+			ConvertToFloat16(h, v)
+			data = bits(h)
+		case DataTypeBFloat16:
+			h := make([]BFloat16, len(v))
+			ConvertToBFloat16(h, v)
+			data = bits(h)
+		}
+	}
+	if dataType == DataTypeString {
+		return nil, fmt.Errorf("string tensors take []string data, got %T", data)
+	}
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice {
+		n := int64(1)
+		for _, d := range shape {
+			n *= d
+		}
+		have := int64(v.Len()) * int64(v.Type().Elem().Size())
+		if want := dataType.ByteSize(n); have < want {
+			return nil, fmt.Errorf("shape %v of %v needs %d bytes, data holds %d", shape, dataType, want, have)
+		}
+	}
+	return data, nil
+}
+
+// boolBytes returns v as the bytes of a DataTypeBoolean tensor.
+func boolBytes(v []bool) []uint8 {
+	b := make([]uint8, len(v))
+	for i, x := range v {
+		if x {
+			b[i] = 1
+		}
+	}
+	return b
+}
+
+// bits returns the half-precision values in v as their bit patterns.
+func bits[T Float16 | BFloat16](v []T) []uint16 {
+	return unsafe.Slice((*uint16)(unsafe.Pointer(unsafe.SliceData(v))), len(v))
 }
 
 // GetSize returns the total number of elements in the tensor.
 func (t *Tensor) GetSize() (int64, error) {
-	return t.tensor.GetSize()
+	const op = "Tensor.GetSize"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return 0, err
+	}
+	defer runtime.KeepAlive(t)
+	size, err := tensor.GetSize()
+	return size, wrapError(err, ErrInvalidTensor, op)
 }
 
 // GetByteSize returns the size of the tensor in bytes.
 func (t *Tensor) GetByteSize() (int64, error) {
-	return t.tensor.GetByteSize()
+	const op = "Tensor.GetByteSize"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return 0, err
+	}
+	defer runtime.KeepAlive(t)
+	size, err := tensor.GetByteSize()
+	return size, wrapError(err, ErrInvalidTensor, op)
 }
 
 // GetElementType returns the data type of the tensor.
 func (t *Tensor) GetElementType() (DataType, error) {
-	dataType, err := t.tensor.GetElementType()
+	const op = "Tensor.GetElementType"
+	tensor, err := t.handle(op)
 	if err != nil {
 		return 0, err
 	}
+	defer runtime.KeepAlive(t)
+	dataType, err := tensor.GetElementType()
+	if err != nil {
+		return 0, wrapError(err, ErrInvalidTensor, op)
+	}
 	return DataType(dataType), nil
 }
 
 // SetShape reshapes the tensor to the new shape.
 func (t *Tensor) SetShape(shape []int64) error {
-	return t.tensor.SetShape(shape)
+	const op = "Tensor.SetShape"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(t)
+	return wrapError(tensor.SetShape(shape), ErrInvalidTensor, op)
+}
+
+// NewTensorFromSlice creates a tensor that uses data as its memory instead of
+// copying it. data must hold exactly the number of elements in shape. It is
+// pinned until the tensor is closed, and writes through either side are seen
+// by the other. The tensor must stay open while an infer request uses it;
+// requests keep the tensors set on them reachable until they are replaced or
+// the request is closed.
+func NewTensorFromSlice[T Element](shape []int64, data []T) (*Tensor, error) {
+	const op = "NewTensorFromSlice"
+	dataType := elementDataType[T]()
+	n := int64(1)
+	for _, d := range shape {
+		if d < 0 {
+			return nil, wrapError(fmt.Errorf("shape %v has a negative dimension", shape), ErrInvalidTensor, op)
+		}
+		n *= d
+	}
+	if int64(len(data)) != n {
+		return nil, wrapError(fmt.Errorf("shape %v needs %d elements, got %d", shape, n, len(data)), ErrInvalidTensor, op)
+	}
+	if n == 0 {
+		return NewTensor(dataType, shape)
+	}
+
+	pinner := new(runtime.Pinner)
+	pinner.Pin(&data[0])
+	tensor, err := cgo.NewTensorFromHostPtr(cgo.DataType(dataType), shape, unsafe.Pointer(&data[0]))
+	if err != nil {
+		pinner.Unpin()
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	t := newTensor(tensor)
+	t.pinner = pinner
+	return t, nil
+}
+
+// TensorView returns the elements of t as a slice that aliases the tensor's
+// memory, without copying. T must match the tensor's element type. Writes to
+// the slice change the tensor. The slice is only valid while t is open and
+// reachable and until it is reshaped; for output tensors, until the next
+// inference overwrites them.
+func TensorView[T Element](t *Tensor) ([]T, error) {
+	const op = "TensorView"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	return view[T](tensor, op)
+}
+
+// TensorData returns a copy of the elements of t. T must match the tensor's
+// element type, e.g. float32 for DataTypeFloat32 or Float16 for
+// DataTypeFloat16. Unlike the GetDataAs methods, which reinterpret the
+// tensor's bytes whatever its element type, it fails with ErrUnsupportedType
+// on a mismatch.
+func TensorData[T Element](t *Tensor) ([]T, error) {
+	return tensorData[T](t, "TensorData")
+}
+
+func tensorData[T Element](t *Tensor, op string) ([]T, error) {
+	tensor, err := t.handle(op)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.KeepAlive(t)
+	v, err := view[T](tensor, op)
+	if err != nil {
+		return nil, err
+	}
+	return append([]T(nil), v...), nil
+}
+
+// CopyTo copies the elements of t into dst, which must be at least as long
+// as the tensor, and returns the number of elements copied. T must match the
+// tensor's element type.
+func CopyTo[T Element](t *Tensor, dst []T) (int, error) {
+	const op = "CopyTo"
+	tensor, err := t.handle(op)
+	if err != nil {
+		return 0, err
+	}
+	defer runtime.KeepAlive(t)
+	v, err := view[T](tensor, op)
+	if err != nil {
+		return 0, err
+	}
+	if len(dst) < len(v) {
+		return 0, wrapError(fmt.Errorf("destination holds %d elements, tensor has %d", len(dst), len(v)), ErrInvalidTensor, op)
+	}
+	return copy(dst, v), nil
+}
+
+// SetInput sets the input with the given tensor name to a tensor of shape
+// holding data. The element type is taken from T. data is copied once,
+// straight into the tensor the request reads.
+func SetInput[T Element](ir *InferRequest, name string, shape []int64, data []T) error {
+	const op = "SetInput"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+
+	tensor, err := NewTensor(elementDataType[T](), shape)
+	if err != nil {
+		return wrapPortError(err, ErrInvalidTensor, op, name)
+	}
+	defer tensor.Close()
+	v, err := view[T](tensor.tensor, op)
+	if err != nil {
+		return err
+	}
+	if len(data) != len(v) {
+		return wrapPortError(fmt.Errorf("shape %v needs %d elements, got %d", shape, len(v), len(data)), ErrInvalidTensor, op, name)
+	}
+	copy(v, data)
+
+	if err := request.SetTensor(name, tensor.tensor); err != nil {
+		return wrapPortError(err, ErrInvalidTensor, op, name)
+	}
+	ir.keep(name)
+	return nil
+}
+
+// view returns the memory of tensor as a []T after checking its element type.
+func view[T Element](tensor *cgo.Tensor, op string) ([]T, error) {
+	return viewAs[T](tensor, op, elementDataType[T]())
+}
+
+// viewAs returns the memory of tensor, which must hold want elements, as a
+// []T. T must have the size of one element.
+func viewAs[T any](tensor *cgo.Tensor, op string, want DataType) ([]T, error) {
+	dataType, err := tensor.GetElementType()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	if DataType(dataType) != want {
+		return nil, wrapError(fmt.Errorf("tensor holds data type %v, not %v", DataType(dataType), want), ErrUnsupportedType, op)
+	}
+	if err := checkContinuous(tensor, op); err != nil {
+		return nil, err
+	}
+	size, err := tensor.GetSize()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	if size == 0 {
+		return []T{}, nil
+	}
+	ptr, err := tensor.DataPointer()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	return unsafe.Slice((*T)(ptr), size), nil
+}
+
+// checkContinuous fails if the elements of tensor, such as a region of
+// interest, are not contiguous in memory.
+func checkContinuous(tensor *cgo.Tensor, op string) error {
+	continuous, err := tensor.IsContinuous()
+	if err != nil {
+		return wrapError(err, ErrInvalidTensor, op)
+	}
+	if !continuous {
+		return wrapError(errors.New("tensor memory is not contiguous; copy it with Tensor.CopyTo first"), ErrInvalidTensor, op)
+	}
+	return nil
 }
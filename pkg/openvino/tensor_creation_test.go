@@ -1,6 +1,7 @@
 package openvino
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -146,3 +147,205 @@ func TestNewTensorWithMultipleDataTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTensorFromSlice(t *testing.T) {
+	data := []float32{1, 2, 3, 4, 5, 6}
+	tensor, err := NewTensorFromSlice([]int64{2, 3}, data)
+	if err != nil {
+		t.Fatalf("NewTensorFromSlice failed: %v", err)
+	}
+	defer tensor.Close()
+
+	dataType, err := tensor.GetElementType()
+	if err != nil {
+		t.Fatalf("GetElementType failed: %v", err)
+	}
+	if dataType != DataTypeFloat32 {
+		t.Errorf("element type = %v, want DataTypeFloat32", dataType)
+	}
+
+	view, err := TensorView[float32](tensor)
+	if err != nil {
+		t.Fatalf("TensorView failed: %v", err)
+	}
+	if len(view) != len(data) || &view[0] != &data[0] {
+		t.Fatal("TensorView does not alias the slice passed to NewTensorFromSlice")
+	}
+	data[1] = 42
+	got, err := tensor.GetDataAsFloat32()
+	if err != nil {
+		t.Fatalf("GetDataAsFloat32 failed: %v", err)
+	}
+	if got[1] != 42 {
+		t.Errorf("tensor did not see a write to the Go slice: got %v", got[1])
+	}
+}
+
+func TestNewTensorFromSlice_sizeMismatch(t *testing.T) {
+	_, err := NewTensorFromSlice([]int64{2, 3}, []int32{1, 2, 3})
+	if !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("got %v, want ErrInvalidTensor", err)
+	}
+	_, err = NewTensorFromSlice([]int64{-1, 3}, []int32{1, 2, 3})
+	if !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("negative dimension: got %v, want ErrInvalidTensor", err)
+	}
+}
+
+func TestTensorView_wrongType(t *testing.T) {
+	tensor, err := NewTensor(DataTypeInt64, []int64{4})
+	if err != nil {
+		t.Fatalf("NewTensor failed: %v", err)
+	}
+	defer tensor.Close()
+
+	if _, err := TensorView[float32](tensor); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("got %v, want ErrUnsupportedType", err)
+	}
+	view, err := TensorView[int64](tensor)
+	if err != nil {
+		t.Fatalf("TensorView failed: %v", err)
+	}
+	view[2] = 7
+	got, err := tensor.GetDataAsInt64()
+	if err != nil {
+		t.Fatalf("GetDataAsInt64 failed: %v", err)
+	}
+	if got[2] != 7 {
+		t.Errorf("write through view not seen by tensor: got %v", got)
+	}
+}
+
+func TestNewTensorFromSlice_infer(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	input, err := NewTensorFromSlice([]int64{1, 4}, []float32{10, 20, 30, 40})
+	if err != nil {
+		t.Fatalf("NewTensorFromSlice failed: %v", err)
+	}
+	defer input.Close()
+	if err := req.SetTensor("input", input); err != nil {
+		t.Fatalf("SetTensor failed: %v", err)
+	}
+	if err := req.Infer(); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	output, err := req.GetOutputTensor("output")
+	if err != nil {
+		t.Fatalf("GetOutputTensor failed: %v", err)
+	}
+	defer output.Close()
+	got, err := TensorView[float32](output)
+	if err != nil {
+		t.Fatalf("TensorView failed: %v", err)
+	}
+	want := []float32{11, 22, 33, 44}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("output = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTensorData(t *testing.T) {
+	halves := []Float16{NewFloat16(1), NewFloat16(-0.5), NewFloat16(3)}
+	tensor, err := NewTensorFromSlice([]int64{3}, halves)
+	if err != nil {
+		t.Fatalf("NewTensorFromSlice failed: %v", err)
+	}
+	defer tensor.Close()
+
+	if dataType, _ := tensor.GetElementType(); dataType != DataTypeFloat16 {
+		t.Errorf("element type = %v, want DataTypeFloat16", dataType)
+	}
+	got, err := TensorData[Float16](tensor)
+	if err != nil {
+		t.Fatalf("TensorData failed: %v", err)
+	}
+	if &got[0] == &halves[0] {
+		t.Error("TensorData returned the tensor memory instead of a copy")
+	}
+	for i := range halves {
+		if got[i] != halves[i] {
+			t.Errorf("element %d = %v, want %v", i, got[i].Float32(), halves[i].Float32())
+		}
+	}
+	if _, err := TensorData[uint16](tensor); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("TensorData[uint16] on a float16 tensor: got %v, want ErrUnsupportedType", err)
+	}
+
+	dst := make([]Float16, 4)
+	n, err := CopyTo(tensor, dst)
+	if err != nil {
+		t.Fatalf("CopyTo failed: %v", err)
+	}
+	if n != 3 || dst[2] != halves[2] {
+		t.Errorf("CopyTo copied %d elements: %v", n, dst)
+	}
+	if _, err := CopyTo(tensor, dst[:2]); !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("CopyTo into a short slice: got %v, want ErrInvalidTensor", err)
+	}
+}
+
+func TestSetInput(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	if err := SetInput(req, "input", []int64{1, 4}, []float32{1, 1, 1}); !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("SetInput with too few elements: got %v, want ErrInvalidTensor", err)
+	}
+	if err := SetInput(req, "input", []int64{1, 4}, []float32{1, 1, 1, 1}); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if err := req.Infer(); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	output, err := req.GetOutputTensor("output")
+	if err != nil {
+		t.Fatalf("GetOutputTensor failed: %v", err)
+	}
+	defer output.Close()
+	got, err := TensorData[float32](output)
+	if err != nil {
+		t.Fatalf("TensorData failed: %v", err)
+	}
+	want := []float32{2, 3, 4, 5}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("output = %v, want %v", got, want)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package openvino
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
 
 func TestTensor_Close(t *testing.T) {
 	tensor := &Tensor{}
@@ -35,7 +39,7 @@ func TestTensor_GetDataAsFloat32(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -87,7 +91,7 @@ func TestTensor_GetDataAsInt64(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -139,7 +143,7 @@ func TestTensor_GetShape(t *testing.T) {
 		shape := inputs[0].Shape
 		size := int64(1)
 		for _, d := range shape {
-			size *= int64(d)
+			size *= d.Length()
 		}
 		data := make([]float32, size)
 		_ = req.SetInputTensor(inputs[0].Name, data, shapeToInt64(shape), inputs[0].DataType)
@@ -161,3 +165,264 @@ func TestTensor_GetShape(t *testing.T) {
 		t.Fatal("GetShape returned nil slice")
 	}
 }
+
+func TestNewTensorFromFloat32(t *testing.T) {
+	data := []float32{1, -0.5, 65504, 1e-7}
+	for _, dataType := range []DataType{DataTypeFloat32, DataTypeFloat16, DataTypeBFloat16} {
+		tensor, err := NewTensorFromFloat32(dataType, []int64{2, 2}, data)
+		if err != nil {
+			t.Fatalf("NewTensorFromFloat32(%v) failed: %v", dataType, err)
+		}
+		got, err := tensor.GetDataConvertedToFloat32()
+		tensor.Close()
+		if err != nil {
+			t.Fatalf("GetDataConvertedToFloat32(%v) failed: %v", dataType, err)
+		}
+		for i, f := range data {
+			want := f
+			switch dataType {
+			case DataTypeFloat16:
+				want = NewFloat16(f).Float32()
+			case DataTypeBFloat16:
+				want = NewBFloat16(f).Float32()
+			}
+			if got[i] != want {
+				t.Errorf("data type %v: element %d = %g, want %g", dataType, i, got[i], want)
+			}
+		}
+	}
+
+	if _, err := NewTensorFromFloat32(DataTypeInt32, []int64{4}, data); err == nil {
+		t.Error("NewTensorFromFloat32(DataTypeInt32) succeeded, want an error")
+	}
+	if _, err := NewTensorFromFloat32(DataTypeFloat16, []int64{3}, data); err == nil {
+		t.Error("NewTensorFromFloat32 with a mismatched shape succeeded, want an error")
+	}
+}
+
+func TestNewTensorWithData_float16(t *testing.T) {
+	tensor, err := NewTensorWithData(DataTypeFloat16, []int64{3}, []float32{1, 2, 3})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer tensor.Close()
+	halves, err := tensor.GetDataAsFloat16()
+	if err != nil {
+		t.Fatalf("GetDataAsFloat16 failed: %v", err)
+	}
+	for i, want := range []Float16{0x3c00, 0x4000, 0x4200} {
+		if halves[i] != want {
+			t.Errorf("element %d = %#04x, want %#04x", i, uint16(halves[i]), uint16(want))
+		}
+	}
+
+	bf, err := NewTensorWithData(DataTypeBFloat16, []int64{2}, []BFloat16{NewBFloat16(1), NewBFloat16(-2)})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer bf.Close()
+	got, err := bf.GetDataAsBFloat16()
+	if err != nil {
+		t.Fatalf("GetDataAsBFloat16 failed: %v", err)
+	}
+	if got[0].Float32() != 1 || got[1].Float32() != -2 {
+		t.Errorf("GetDataAsBFloat16 = %v", got)
+	}
+}
+
+func TestTensor_boolean(t *testing.T) {
+	tensor, err := NewTensorWithData(DataTypeBoolean, []int64{4}, []bool{true, false, false, true})
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer tensor.Close()
+	got, err := tensor.GetDataAsBool()
+	if err != nil {
+		t.Fatalf("GetDataAsBool failed: %v", err)
+	}
+	if len(got) != 4 || !got[0] || got[1] || got[2] || !got[3] {
+		t.Errorf("GetDataAsBool = %v, want [true false false true]", got)
+	}
+	if raw, err := tensor.GetDataAsUint8(); err != nil || !reflect.DeepEqual(raw, []uint8{1, 0, 0, 1}) {
+		t.Errorf("GetDataAsUint8 on a boolean tensor = %v, %v, want its bytes", raw, err)
+	}
+	if _, err := TensorData[uint8](tensor); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("TensorData[uint8] on a boolean tensor: got %v, want ErrUnsupportedType", err)
+	}
+}
+
+func TestTensor_string(t *testing.T) {
+	data := []string{"hello", "", "wörld"}
+	tensor, err := NewTensorWithData(DataTypeString, []int64{1, 3}, data)
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer tensor.Close()
+	if dataType, _ := tensor.GetElementType(); dataType != DataTypeString {
+		t.Errorf("element type = %v, want string", dataType)
+	}
+	got, err := tensor.GetDataAsString()
+	if err != nil {
+		t.Fatalf("GetDataAsString failed: %v", err)
+	}
+	for i := range data {
+		if got[i] != data[i] {
+			t.Errorf("element %d = %q, want %q", i, got[i], data[i])
+		}
+	}
+
+	if _, err := NewTensorFromStrings([]int64{2}, data); err == nil {
+		t.Error("NewTensorFromStrings with a mismatched shape succeeded, want an error")
+	}
+	if _, err := NewTensorWithData(DataTypeString, []int64{3}, []uint8{1, 2, 3}); !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("NewTensorWithData(string, []uint8): got %v, want ErrInvalidTensor", err)
+	}
+}
+
+func TestTensor_packed(t *testing.T) {
+	// Five u4 elements occupy three bytes.
+	packed := []uint8{0x21, 0x43, 0x05}
+	tensor, err := NewTensorWithData(DataTypeUint4, []int64{5}, packed)
+	if err != nil {
+		t.Fatalf("NewTensorWithData failed: %v", err)
+	}
+	defer tensor.Close()
+	if size, _ := tensor.GetByteSize(); size != 3 {
+		t.Errorf("GetByteSize = %d, want 3", size)
+	}
+	got, err := tensor.GetDataAsBytes()
+	if err != nil {
+		t.Fatalf("GetDataAsBytes failed: %v", err)
+	}
+	if len(got) != 3 || got[0] != 0x21 || got[1] != 0x43 || got[2]&0x0f != 0x05 {
+		t.Errorf("GetDataAsBytes = %#v, want %#v", got, packed)
+	}
+
+	if _, err := NewTensorWithData(DataTypeUint4, []int64{8}, packed); !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("NewTensorWithData with too few packed bytes: got %v, want ErrInvalidTensor", err)
+	}
+}
+
+func TestTensor_ROI(t *testing.T) {
+	data := make([]float32, 16)
+	for i := range data {
+		data[i] = float32(i)
+	}
+	tensor, err := NewTensorFromSlice([]int64{4, 4}, data)
+	if err != nil {
+		t.Fatalf("NewTensorFromSlice failed: %v", err)
+	}
+	defer tensor.Close()
+
+	strides, err := tensor.Strides()
+	if err != nil {
+		t.Fatalf("Strides failed: %v", err)
+	}
+	if len(strides) != 2 || strides[0] != 16 || strides[1] != 4 {
+		t.Errorf("Strides = %v, want [16 4]", strides)
+	}
+
+	roi, err := tensor.ROI([]int64{1, 1}, []int64{3, 3})
+	if err != nil {
+		t.Fatalf("ROI failed: %v", err)
+	}
+	defer roi.Close()
+	if shape, _ := roi.GetShape(); len(shape) != 2 || shape[0] != 2 || shape[1] != 2 {
+		t.Errorf("ROI shape = %v, want [2 2]", shape)
+	}
+	if continuous, err := roi.IsContinuous(); err != nil || continuous {
+		t.Errorf("IsContinuous = %v, %v, want false", continuous, err)
+	}
+	if _, err := TensorView[float32](roi); !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("TensorView of an ROI: got %v, want ErrInvalidTensor", err)
+	}
+	if _, err := roi.GetDataAsFloat32(); !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("GetDataAsFloat32 of an ROI: got %v, want ErrInvalidTensor", err)
+	}
+
+	tile := make([]float32, 4)
+	dst, err := NewTensorFromSlice([]int64{2, 2}, tile)
+	if err != nil {
+		t.Fatalf("NewTensorFromSlice failed: %v", err)
+	}
+	defer dst.Close()
+	if err := roi.CopyTo(dst); err != nil {
+		t.Fatalf("CopyTo failed: %v", err)
+	}
+	for i, want := range []float32{5, 6, 9, 10} {
+		if tile[i] != want {
+			t.Errorf("tile = %v, want [5 6 9 10]", tile)
+			break
+		}
+	}
+
+	// Writes through a region reach the parent tensor.
+	for i := range tile {
+		tile[i] = -1
+	}
+	if err := dst.CopyTo(roi); err != nil {
+		t.Fatalf("CopyTo an ROI failed: %v", err)
+	}
+	if data[5] != -1 || data[10] != -1 || data[4] != 4 || data[11] != 11 {
+		t.Errorf("parent after writing the ROI = %v", data)
+	}
+
+	if _, err := tensor.ROI([]int64{0}, []int64{5, 5}); err == nil {
+		t.Error("ROI with mismatched begin and end succeeded, want an error")
+	}
+	if err := tensor.CopyTo(nil); !errors.Is(err, ErrInvalidTensor) {
+		t.Errorf("CopyTo(nil): got %v, want ErrInvalidTensor", err)
+	}
+}
+
+func TestTensor_ROI_setTensor(t *testing.T) {
+	core := coreAvailable(t)
+	defer core.Close()
+
+	model := readTestIRModel(t, core)
+	defer model.Close()
+
+	compiled, err := core.CompileModel(model, "CPU")
+	if err != nil {
+		t.Skipf("CompileModel failed: %v", err)
+	}
+	defer compiled.Close()
+
+	req, err := compiled.CreateInferRequest()
+	if err != nil {
+		t.Fatalf("CreateInferRequest failed: %v", err)
+	}
+	defer req.Close()
+
+	image, err := NewTensorFromSlice([]int64{2, 4}, []float32{0, 0, 0, 0, 10, 20, 30, 40})
+	if err != nil {
+		t.Fatalf("NewTensorFromSlice failed: %v", err)
+	}
+	defer image.Close()
+	row, err := image.ROI([]int64{1, 0}, []int64{2, 4})
+	if err != nil {
+		t.Fatalf("ROI failed: %v", err)
+	}
+	defer row.Close()
+
+	if err := req.SetTensor("input", row); err != nil {
+		t.Fatalf("SetTensor failed: %v", err)
+	}
+	if err := req.Infer(); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	output, err := req.GetOutputTensor("output")
+	if err != nil {
+		t.Fatalf("GetOutputTensor failed: %v", err)
+	}
+	defer output.Close()
+	got, err := output.GetDataAsFloat32()
+	if err != nil {
+		t.Fatalf("GetDataAsFloat32 failed: %v", err)
+	}
+	for i, want := range []float32{11, 22, 33, 44} {
+		if got[i] != want {
+			t.Fatalf("output = %v, want [11 22 33 44]", got)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package openvino
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TraceTrack identifies where an inference is drawn in a Chrome trace. Each
+// stream is shown as a process and each request as a thread within it.
+// OpenVINO does not report which stream ran a request, so Stream is chosen by
+// the caller; leave it zero to put every request in one group.
+type TraceTrack struct {
+	Request int
+	Stream  int
+}
+
+// TraceRecorder collects per-node profiling timelines from one or many
+// infer requests and writes them in the Chrome Trace Event format, which
+// chrome://tracing and Perfetto can open. Profiling must be enabled with
+// EnableProfiling. It is safe for concurrent use.
+//
+// OpenVINO reports node durations but not start times, so the nodes of an
+// inference are laid out back to back from its start, in the order
+// GetProfilingInfo returns them.
+type TraceRecorder struct {
+	mu         sync.Mutex
+	inferences []tracedInference
+}
+
+type tracedInference struct {
+	track TraceTrack
+	start time.Time
+	wall  time.Duration
+	infos []ProfilingInfo
+}
+
+// NewTraceRecorder returns an empty recorder.
+func NewTraceRecorder() *TraceRecorder {
+	return &TraceRecorder{}
+}
+
+// Record reads the profiling info of req's last inference, which started at
+// start and took wall, and adds it on track.
+func (r *TraceRecorder) Record(req *InferRequest, track TraceTrack, start time.Time, wall time.Duration) error {
+	infos, err := req.GetProfilingInfo()
+	if err != nil {
+		return err
+	}
+	r.Add(infos, track, start, wall)
+	return nil
+}
+
+// Add adds one inference's profiling info on track. If wall is shorter than
+// the summed node time, the inference slice is stretched to cover its nodes.
+func (r *TraceRecorder) Add(infos []ProfilingInfo, track TraceTrack, start time.Time, wall time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inferences = append(r.inferences, tracedInference{
+		track: track,
+		start: start,
+		wall:  wall,
+		infos: append([]ProfilingInfo(nil), infos...),
+	})
+}
+
+// traceEvent is one entry of the Chrome Trace Event format. Times are in
+// microseconds.
+type traceEvent struct {
+	Name string                 `json:"name"`
+	Cat  string                 `json:"cat,omitempty"`
+	Ph   string                 `json:"ph"`
+	Ts   int64                  `json:"ts"`
+	Dur  int64                  `json:"dur"`
+	Pid  int                    `json:"pid"`
+	Tid  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+type traceFile struct {
+	TraceEvents     []traceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+// WriteChromeTrace writes the recorded inferences as Chrome Trace Event JSON.
+// Timestamps are relative to the earliest recorded inference.
+func (r *TraceRecorder) WriteChromeTrace(w io.Writer) error {
+	r.mu.Lock()
+	inferences := append([]tracedInference(nil), r.inferences...)
+	r.mu.Unlock()
+
+	sort.SliceStable(inferences, func(i, j int) bool {
+		return inferences[i].start.Before(inferences[j].start)
+	})
+
+	events := make([]traceEvent, 0)
+	streams := make(map[int]bool)
+	requests := make(map[TraceTrack]bool)
+	for n, inf := range inferences {
+		if !streams[inf.track.Stream] {
+			streams[inf.track.Stream] = true
+			events = append(events, traceEvent{
+				Name: "process_name", Ph: "M", Pid: inf.track.Stream,
+				Args: map[string]interface{}{"name": fmt.Sprintf("stream %d", inf.track.Stream)},
+			})
+		}
+		if !requests[inf.track] {
+			requests[inf.track] = true
+			events = append(events, traceEvent{
+				Name: "thread_name", Ph: "M", Pid: inf.track.Stream, Tid: inf.track.Request,
+				Args: map[string]interface{}{"name": fmt.Sprintf("request %d", inf.track.Request)},
+			})
+		}
+
+		ts := inf.start.Sub(inferences[0].start).Microseconds()
+		var nodes []traceEvent
+		offset := ts
+		for _, info := range inf.infos {
+			if info.Status != ProfilingInfoStatusExecuted {
+				continue
+			}
+			nodes = append(nodes, traceEvent{
+				Name: info.NodeName,
+				Cat:  info.NodeType,
+				Ph:   "X",
+				Ts:   offset,
+				Dur:  info.RealTime,
+				Pid:  inf.track.Stream,
+				Tid:  inf.track.Request,
+				Args: map[string]interface{}{
+					"node_type":   info.NodeType,
+					"exec_type":   info.ExecType,
+					"cpu_time_us": info.CPUTime,
+				},
+			})
+			offset += info.RealTime
+		}
+
+		dur := inf.wall.Microseconds()
+		if dur < offset-ts {
+			dur = offset - ts
+		}
+		events = append(events, traceEvent{
+			Name: "infer",
+			Cat:  "inference",
+			Ph:   "X",
+			Ts:   ts,
+			Dur:  dur,
+			Pid:  inf.track.Stream,
+			Tid:  inf.track.Request,
+			Args: map[string]interface{}{"index": n},
+		})
+		events = append(events, nodes...)
+	}
+
+	return json.NewEncoder(w).Encode(traceFile{TraceEvents: events, DisplayTimeUnit: "ms"})
+}
@@ -0,0 +1,91 @@
+package openvino
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTraceRecorder_WriteChromeTrace(t *testing.T) {
+	rec := NewTraceRecorder()
+	start := time.Unix(1000, 0)
+	rec.Add(testProfile(100, 10), TraceTrack{Request: 1}, start.Add(5*time.Millisecond), time.Millisecond)
+	rec.Add(testProfile(200, 20), TraceTrack{Request: 0, Stream: 1}, start, 0)
+
+	var buf bytes.Buffer
+	if err := rec.WriteChromeTrace(&buf); err != nil {
+		t.Fatalf("WriteChromeTrace failed: %v", err)
+	}
+
+	var trace struct {
+		TraceEvents []struct {
+			Name string                 `json:"name"`
+			Ph   string                 `json:"ph"`
+			Ts   int64                  `json:"ts"`
+			Dur  int64                  `json:"dur"`
+			Pid  int                    `json:"pid"`
+			Tid  int                    `json:"tid"`
+			Args map[string]interface{} `json:"args"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("decoding trace failed: %v", err)
+	}
+
+	var meta, slices int
+	for _, ev := range trace.TraceEvents {
+		switch ev.Ph {
+		case "M":
+			meta++
+		case "X":
+			slices++
+		default:
+			t.Errorf("unexpected phase %q", ev.Ph)
+		}
+	}
+	// Two streams and two requests; per inference one infer slice and three
+	// executed nodes.
+	if meta != 4 || slices != 8 {
+		t.Fatalf("got %d metadata and %d slice events, want 4 and 8", meta, slices)
+	}
+
+	want := []struct {
+		name    string
+		ts, dur int64
+		pid     int
+		tid     int
+	}{
+		{"infer", 0, 420, 1, 0},
+		{"conv1", 0, 200, 1, 0},
+		{"conv2", 200, 200, 1, 0},
+		{"relu", 400, 20, 1, 0},
+		{"infer", 5000, 1000, 0, 1},
+		{"conv1", 5000, 100, 0, 1},
+	}
+	var got []int
+	for i, ev := range trace.TraceEvents {
+		if ev.Ph == "X" {
+			got = append(got, i)
+		}
+	}
+	for i, w := range want {
+		ev := trace.TraceEvents[got[i]]
+		if ev.Name != w.name || ev.Ts != w.ts || ev.Dur != w.dur || ev.Pid != w.pid || ev.Tid != w.tid {
+			t.Errorf("slice %d = %s ts=%d dur=%d pid=%d tid=%d, want %+v", i, ev.Name, ev.Ts, ev.Dur, ev.Pid, ev.Tid, w)
+		}
+	}
+	if ev := trace.TraceEvents[got[1]]; ev.Args["exec_type"] != "jit_avx2_FP32" {
+		t.Errorf("node args = %v, want exec_type", ev.Args)
+	}
+}
+
+func TestTraceRecorder_empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewTraceRecorder().WriteChromeTrace(&buf); err != nil {
+		t.Fatalf("WriteChromeTrace failed: %v", err)
+	}
+	if got := buf.String(); got != "{\"traceEvents\":[],\"displayTimeUnit\":\"ms\"}\n" {
+		t.Errorf("empty trace = %q", got)
+	}
+}
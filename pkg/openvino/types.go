@@ -5,24 +5,49 @@ import "github.com/accretional/openvino-go/internal/cgo"
 type DataType = cgo.DataType
 
 const (
-	DataTypeFloat32  = cgo.DataTypeFloat32
-	DataTypeInt64    = cgo.DataTypeInt64
-	DataTypeInt32    = cgo.DataTypeInt32
-	DataTypeUint8    = cgo.DataTypeUint8
-	DataTypeFloat64  = cgo.DataTypeFloat64
-	DataTypeInt8     = cgo.DataTypeInt8
-	DataTypeUint16   = cgo.DataTypeUint16
-	DataTypeInt16    = cgo.DataTypeInt16
-	DataTypeUint32   = cgo.DataTypeUint32
-	DataTypeUint64   = cgo.DataTypeUint64
-	DataTypeFloat16  = cgo.DataTypeFloat16
-	DataTypeBFloat16 = cgo.DataTypeBFloat16
+	DataTypeFloat32    = cgo.DataTypeFloat32
+	DataTypeInt64      = cgo.DataTypeInt64
+	DataTypeInt32      = cgo.DataTypeInt32
+	DataTypeUint8      = cgo.DataTypeUint8
+	DataTypeFloat64    = cgo.DataTypeFloat64
+	DataTypeInt8       = cgo.DataTypeInt8
+	DataTypeUint16     = cgo.DataTypeUint16
+	DataTypeInt16      = cgo.DataTypeInt16
+	DataTypeUint32     = cgo.DataTypeUint32
+	DataTypeUint64     = cgo.DataTypeUint64
+	DataTypeFloat16    = cgo.DataTypeFloat16
+	DataTypeBFloat16   = cgo.DataTypeBFloat16
+	DataTypeBoolean    = cgo.DataTypeBoolean
+	DataTypeString     = cgo.DataTypeString
+	DataTypeFloat8E4M3 = cgo.DataTypeFloat8E4M3
+	DataTypeFloat8E5M2 = cgo.DataTypeFloat8E5M2
+	// DataTypeFloat8E8M0 and DataTypeFloat4E2M1 need OpenVINO 2025.0.
+	DataTypeFloat8E8M0 = cgo.DataTypeFloat8E8M0
+	DataTypeFloat4E2M1 = cgo.DataTypeFloat4E2M1
+
+	// Packed types store several elements per byte. Tensors of these types
+	// take and return their data as packed []uint8.
+	DataTypeUint1 = cgo.DataTypeUint1
+	DataTypeUint2 = cgo.DataTypeUint2
+	DataTypeUint3 = cgo.DataTypeUint3
+	DataTypeUint4 = cgo.DataTypeUint4
+	DataTypeUint6 = cgo.DataTypeUint6
+	DataTypeInt4  = cgo.DataTypeInt4
+	DataTypeNF4   = cgo.DataTypeNF4
+
+	// DataTypeDynamic is reported for ports whose element type is not
+	// known until inference.
+	DataTypeDynamic = cgo.DataTypeDynamic
 )
 
+// PortInfo describes a model input or output. Shape carries OpenVINO's
+// interval bounds for each dimension and is nil when the rank is dynamic.
+// Layout is empty unless one was set on the port.
 type PortInfo struct {
 	Name     string
-	Shape    []int32
+	Shape    PartialShape
 	DataType DataType
+	Layout   Layout
 }
 
 type PerformanceMode string
@@ -1,6 +1,10 @@
 package openvino
 
-import "github.com/accretional/openvino-go/internal/cgo"
+import (
+	"runtime"
+
+	"github.com/accretional/openvino-go/internal/cgo"
+)
 
 // VariableState represents a variable state in a stateful model.
 // VariableState is only available for models that contain ReadValue and Assign operations.
@@ -19,14 +23,20 @@ type VariableState struct {
 
 // QueryState queries all variable states from the infer request.
 func (ir *InferRequest) QueryState() ([]*VariableState, error) {
-	cgoStates, err := ir.request.QueryState()
+	const op = "InferRequest.QueryState"
+	request, err := ir.handle(op)
 	if err != nil {
 		return nil, err
 	}
+	defer runtime.KeepAlive(ir)
+	cgoStates, err := request.QueryState()
+	if err != nil {
+		return nil, wrapError(err, nil, op)
+	}
 
 	states := make([]*VariableState, len(cgoStates))
 	for i, cgoState := range cgoStates {
-		states[i] = &VariableState{state: cgoState}
+		states[i] = trackHandle(&VariableState{state: cgoState}, "VariableState", (*VariableState).Close)
 	}
 
 	return states, nil
@@ -34,36 +44,85 @@ func (ir *InferRequest) QueryState() ([]*VariableState, error) {
 
 // ResetState resets all variable states to their default values.
 func (ir *InferRequest) ResetState() error {
-	return ir.request.ResetState()
+	const op = "InferRequest.ResetState"
+	request, err := ir.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(ir)
+	return wrapError(request.ResetState(), nil, op)
+}
+
+func (vs *VariableState) handle(op string) (*cgo.VariableState, error) {
+	if vs.state == nil {
+		return nil, closedError(op, "VariableState")
+	}
+	return vs.state, nil
 }
 
 // GetName returns the name of the variable state.
 func (vs *VariableState) GetName() (string, error) {
-	return vs.state.GetName()
+	const op = "VariableState.GetName"
+	state, err := vs.handle(op)
+	if err != nil {
+		return "", err
+	}
+	defer runtime.KeepAlive(vs)
+	name, err := state.GetName()
+	if err != nil {
+		return "", wrapError(err, nil, op)
+	}
+	return name, nil
 }
 
 // GetState returns the current state tensor.
 func (vs *VariableState) GetState() (*Tensor, error) {
-	tensor, err := vs.state.GetState()
+	const op = "VariableState.GetState"
+	state, err := vs.handle(op)
 	if err != nil {
 		return nil, err
 	}
-	return &Tensor{tensor: tensor}, nil
+	defer runtime.KeepAlive(vs)
+	tensor, err := state.GetState()
+	if err != nil {
+		return nil, wrapError(err, ErrInvalidTensor, op)
+	}
+	return newTensor(tensor), nil
 }
 
 // SetState sets the state tensor for the next inference.
 func (vs *VariableState) SetState(tensor *Tensor) error {
-	return vs.state.SetState(tensor.tensor)
+	const op = "VariableState.SetState"
+	state, err := vs.handle(op)
+	if err != nil {
+		return err
+	}
+	t, err := tensorArg(tensor, op, "")
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(vs)
+	defer runtime.KeepAlive(tensor)
+	return wrapError(state.SetState(t), ErrInvalidTensor, op)
 }
 
 // Reset resets the variable state to its default value.
 func (vs *VariableState) Reset() error {
-	return vs.state.Reset()
+	const op = "VariableState.Reset"
+	state, err := vs.handle(op)
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(vs)
+	return wrapError(state.Reset(), nil, op)
 }
 
-// Close releases the VariableState resources.
+// Close releases the VariableState resources. It is safe to call Close more
+// than once.
 func (vs *VariableState) Close() {
 	if vs.state != nil {
+		runtime.SetFinalizer(vs, nil)
 		vs.state.Destroy()
+		vs.state = nil
 	}
 }
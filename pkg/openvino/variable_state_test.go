@@ -158,7 +158,7 @@ func TestVariableState_API(t *testing.T) {
 		t.Fatalf("GetElementType failed: %v", err)
 	}
 
-	newTensor, err := NewTensor(dataType, shapeToInt64(shape))
+	newTensor, err := NewTensor(dataType, shape)
 	if err != nil {
 		t.Fatalf("NewTensor failed: %v", err)
 	}